@@ -0,0 +1,99 @@
+package injector
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// RegisterAdapter registers an adapter function in the form of func(C) I or func(C) (I, error).
+// When injecting by types, if no component matches the requested type, adapters whose I matches
+// the requested type are used to adapt a component matching C. Types are matched by the matcher
+// set via WithMatcher, assignability by default. A direct match always takes
+// precedence over adapters. It's a conflict if more than one component can be adapted.
+// The adapter is invoked for every injection so adapted values aren't shared.
+func (c *Injector) RegisterAdapter(adapterFn interface{}) {
+	defer annotatePanic("RegisterAdapter", "")
+	defer c.recordOperation("RegisterAdapter", adapterFn)()
+
+	c.validateFrozen()
+
+	fnType := reflect.TypeOf(adapterFn)
+	if fnType == nil || fnType.Kind() != reflect.Func || fnType.NumIn() != 1 ||
+		fnType.NumOut() < 1 || fnType.NumOut() > 2 ||
+		(fnType.NumOut() == 2 && !implementsError(fnType.Out(1))) {
+		throw(errors.New("injector: an adapter function in the form of func(C) I or func(C) (I, error) is expected"))
+	}
+
+	c.adapters = append(c.adapters, reflect.ValueOf(adapterFn))
+}
+
+// adapt adapts a component to type t. It returns a nil dependency if there is no eligible adapter.
+// The returned name is the name of the adapted component.
+func (c *Injector) adapt(t reflect.Type) (string, *dependency, error) {
+	foundAdapter, foundVal, conflicted := c.findAdapter(t)
+	if conflicted {
+		return "", nil, errAdapterConflict(t)
+	}
+
+	if foundVal == nil {
+		return "", nil, nil
+	}
+
+	foundVal, err := c.resolve(foundVal.name, foundVal)
+	if err != nil {
+		return "", nil, err
+	}
+
+	in, err := convertValue(foundVal.reflectValue, foundAdapter.Type().In(0))
+	if err != nil {
+		return "", nil, err
+	}
+
+	out := foundAdapter.Call([]reflect.Value{in})
+	if len(out) == 2 && !out[1].IsNil() {
+		return "", nil, out[1].Interface().(error)
+	}
+
+	return foundVal.name, &dependency{
+		value:        out[0].Interface(),
+		reflectValue: out[0],
+		reflectType:  out[0].Type(),
+		dependsOn:    []string{foundVal.name},
+	}, nil
+}
+
+// findAdapter returns the adapter and the component it adapts to type t without invoking the adapter.
+// conflicted is true if more than one component can be adapted.
+func (c *Injector) findAdapter(t reflect.Type) (foundAdapter reflect.Value, foundVal *dependency, conflicted bool) {
+	c.forEachAdapter(func(adapter reflect.Value) {
+		adapterType := adapter.Type()
+		if !c.matcher(adapterType.Out(0), t) {
+			return
+		}
+
+		c.forEach(func(v *dependency) {
+			if !v.placeholder && c.matcher(v.reflectType, adapterType.In(0)) {
+				conflicted = conflicted || foundVal != nil
+				foundAdapter = adapter
+				foundVal = v
+			}
+		})
+	})
+
+	return foundAdapter, foundVal, conflicted
+}
+
+func errAdapterConflict(t reflect.Type) error {
+	return fmt.Errorf("injector: there is a conflict when adapting a dependency for %s", t)
+}
+
+func (c *Injector) forEachAdapter(fn func(adapter reflect.Value)) {
+	for _, adapter := range c.adapters {
+		fn(adapter)
+	}
+
+	for _, source := range c.sources {
+		source.forEachAdapter(fn)
+	}
+}
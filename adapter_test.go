@@ -0,0 +1,98 @@
+package injector
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type legacyPrinter struct {
+	text string
+}
+
+func adaptLegacyPrinter(p *legacyPrinter) Renderer {
+	return mockRenderer(p.text)
+}
+
+func Test_RegisterAdapter(t *testing.T) {
+	t.Run("adapted", func(t *testing.T) {
+		c := New()
+		c.RegisterAdapter(adaptLegacyPrinter)
+		c.NamedComponent("printer", &legacyPrinter{text: "adapted"})
+
+		target := &struct {
+			Renderer Renderer `injector:"auto"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, "adapted", target.Renderer.Render())
+
+		name, err := c.ResolveAuto(reflectTypeOfRenderer)
+		require.NoError(t, err)
+		require.Equal(t, "printer", name)
+
+		name = c.ComponentFromFunc(func(r Renderer) string {
+			return r.Render()
+		})
+		require.Equal(t, "adapted", c.Get(name))
+		require.Equal(t, []string{name}, c.Dependents("printer"))
+	})
+
+	t.Run("direct-match-first", func(t *testing.T) {
+		c := New()
+		c.RegisterAdapter(adaptLegacyPrinter)
+		c.NamedComponent("printer", &legacyPrinter{text: "adapted"})
+		c.NamedComponent("renderer", mockRenderer("direct"))
+
+		target := &struct {
+			Renderer Renderer `injector:"auto"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, "direct", target.Renderer.Render())
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		c := New()
+		c.RegisterAdapter(adaptLegacyPrinter)
+		c.NamedComponent("printer-1", &legacyPrinter{text: "1"})
+		c.NamedComponent("printer-2", &legacyPrinter{text: "2"})
+		require.PanicsWithError(t, "injector: there is a conflict when adapting a dependency for injector.Renderer", func() {
+			c.Inject(&struct {
+				Renderer Renderer `injector:"auto"`
+			}{})
+		})
+	})
+
+	t.Run("adapter-error", func(t *testing.T) {
+		c := New()
+		c.RegisterAdapter(func(p *legacyPrinter) (Renderer, error) {
+			return nil, errors.New("random error")
+		})
+		c.NamedComponent("printer", &legacyPrinter{})
+		require.PanicsWithError(t, "random error", func() {
+			c.Inject(&struct {
+				Renderer Renderer `injector:"auto"`
+			}{})
+		})
+	})
+
+	t.Run("no-adaptable-component", func(t *testing.T) {
+		c := New()
+		c.RegisterAdapter(adaptLegacyPrinter)
+		require.PanicsWithError(t, "injector: couldn't find the dependency for injector.Renderer", func() {
+			c.Inject(&struct {
+				Renderer Renderer `injector:"auto"`
+			}{})
+		})
+	})
+
+	t.Run("invalid-adapter", func(t *testing.T) {
+		c := New()
+		for _, adapter := range []interface{}{10, func() Renderer { return nil }, func(p *legacyPrinter) (Renderer, int) { return nil, 0 }} {
+			require.PanicsWithError(t, "injector: an adapter function in the form of func(C) I or func(C) (I, error) is expected", func() {
+				c.RegisterAdapter(adapter)
+			}, fmt.Sprintf("%T", adapter))
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package injector
+
+import (
+	"errors"
+)
+
+// Builder accumulates registrations and applies them to a new Injector in Build.
+// Unlike the Injector, errors of all registrations are returned by Build at once instead of panicking.
+// Panics which aren't raised by the Injector, e.g. from factory functions, still propagate:
+//
+//	c, err := injector.NewBuilder().
+//	  NamedComponent("logger", newLogger).
+//	  Component(&ServiceImpl{}).
+//	  Build()
+type Builder struct {
+	opts  []Option
+	steps []func(c *Injector)
+}
+
+// NewBuilder creates a new Builder, the given options are used to create the Injector.
+func NewBuilder(opts ...Option) *Builder {
+	return &Builder{
+		opts: opts,
+	}
+}
+
+// Component adds a registration similar to Injector.Component.
+func (b *Builder) Component(dep interface{}) *Builder {
+	return b.add(func(c *Injector) {
+		c.Component(dep)
+	})
+}
+
+// NamedComponent adds a registration similar to Injector.NamedComponent.
+func (b *Builder) NamedComponent(name string, dep interface{}) *Builder {
+	return b.add(func(c *Injector) {
+		c.NamedComponent(name, dep)
+	})
+}
+
+// ComponentFromFunc adds a registration similar to Injector.ComponentFromFunc.
+func (b *Builder) ComponentFromFunc(factoryFn interface{}) *Builder {
+	return b.add(func(c *Injector) {
+		c.ComponentFromFunc(factoryFn)
+	})
+}
+
+// NamedComponentFromFunc adds a registration similar to Injector.NamedComponentFromFunc.
+func (b *Builder) NamedComponentFromFunc(name string, factoryFn interface{}) *Builder {
+	return b.add(func(c *Injector) {
+		c.NamedComponentFromFunc(name, factoryFn)
+	})
+}
+
+// ProvideInto adds a factory function to a collection similar to Injector.ProvideInto.
+func (b *Builder) ProvideInto(name string, factoryFn interface{}) *Builder {
+	return b.add(func(c *Injector) {
+		c.ProvideInto(name, factoryFn)
+	})
+}
+
+// ProvideSlice declares a collection similar to Injector.ProvideSlice.
+func (b *Builder) ProvideSlice(name string, factoryFns ...interface{}) *Builder {
+	return b.add(func(c *Injector) {
+		c.ProvideSlice(name, factoryFns...)
+	})
+}
+
+// NamedPrototypeFromFunc adds a prototype similar to Injector.NamedPrototypeFromFunc.
+func (b *Builder) NamedPrototypeFromFunc(name string, factoryFn interface{}) *Builder {
+	return b.add(func(c *Injector) {
+		c.NamedPrototypeFromFunc(name, factoryFn)
+	})
+}
+
+// RegisterPlaceholder adds a placeholder similar to Injector.RegisterPlaceholder,
+// it must be fulfilled via Fulfill before Build.
+func (b *Builder) RegisterPlaceholder(name string, ifacePtr interface{}) *Builder {
+	return b.add(func(c *Injector) {
+		c.RegisterPlaceholder(name, ifacePtr)
+	})
+}
+
+// Fulfill provides the value of a placeholder similar to Injector.Fulfill.
+func (b *Builder) Fulfill(name string, dep interface{}) *Builder {
+	return b.add(func(c *Injector) {
+		c.Fulfill(name, dep)
+	})
+}
+
+// Apply adds modules which are applied similar to Injector.Apply.
+func (b *Builder) Apply(modules ...Module) *Builder {
+	return b.add(func(c *Injector) {
+		if err := c.Apply(modules...); err != nil {
+			throw(err)
+		}
+	})
+}
+
+// Build creates a new Injector and applies registrations in the order they're added.
+// Remaining registrations are still applied if a registration fails. Placeholders which aren't fulfilled
+// are reported as well, so the Injector is ready to use. All errors are joined into the returned error
+// and the Injector is nil in that case.
+func (b *Builder) Build() (*Injector, error) {
+	var c *Injector
+	if err := Run(func() {
+		c = New(b.opts...)
+	}); err != nil {
+		return nil, err
+	}
+
+	errs := []error{}
+	for _, step := range b.steps {
+		if err := Run(func() {
+			step(c)
+		}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, name := range c.names {
+		if err := validateFulfilled(name, c.dependencies[name]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return c, nil
+}
+
+func (b *Builder) add(step func(c *Injector)) *Builder {
+	b.steps = append(b.steps, step)
+	return b
+}
@@ -0,0 +1,83 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Builder(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		c, err := NewBuilder(WithBuildInfo(BuildInfo{Version: "v1.0.0"})).
+			NamedComponent("mocked-int", 10).
+			Component(&TypeA{}).
+			NamedComponentFromFunc("from-func", func(a *TypeA) string {
+				return "created"
+			}).
+			ComponentFromFunc(func() Renderer {
+				return mockRenderer("from-func")
+			}).
+			ProvideInto("renderers", func(r Renderer) Renderer {
+				return r
+			}).
+			Apply(typeAModule{}).
+			Build()
+		require.NoError(t, err)
+		require.Equal(t, "created", c.Get("from-func"))
+		require.Equal(t, 10, c.Get("type-a").(*TypeA).Field)
+		require.Equal(t, []interface{}{mockRenderer("from-func")}, c.Get("renderers"))
+	})
+
+	t.Run("aggregated-errors", func(t *testing.T) {
+		c, err := NewBuilder().
+			NamedComponent("type-a", &TypeA{}).
+			NamedComponent("mocked-int", 10).
+			NamedComponent("mocked-int", 11).
+			Build()
+		require.EqualError(t, err, "injector: mocked-int is not registered\n"+
+			"injector: mocked-int is already registered")
+		require.Nil(t, c)
+	})
+
+	t.Run("nil-factory", func(t *testing.T) {
+		c, err := NewBuilder().
+			ProvideInto("renderers", nil).
+			NamedComponentFromFunc("renderer", nil).
+			ComponentFromFunc(nil).
+			Build()
+		require.EqualError(t, err, "injector: a factory function is expected\n"+
+			"injector: a factory function is expected\n"+
+			"injector: a factory function is expected")
+		require.Nil(t, c)
+	})
+
+	t.Run("placeholders-and-prototypes", func(t *testing.T) {
+		c, err := NewBuilder().
+			NamedComponent("mocked-int", 10).
+			RegisterPlaceholder("renderer", (*Renderer)(nil)).
+			NamedPrototypeFromFunc("type-a", func() *TypeA { return &TypeA{} }).
+			ProvideSlice("numbers", func(v int) int { return v }).
+			Fulfill("renderer", &rendererImpl{}).
+			Build()
+		require.NoError(t, err)
+		require.Equal(t, "rendered", c.Get("renderer").(Renderer).Render())
+		require.NotSame(t, c.Get("type-a"), c.Get("type-a"))
+		require.Equal(t, []interface{}{10}, c.Get("numbers"))
+	})
+
+	t.Run("unfulfilled-placeholder", func(t *testing.T) {
+		c, err := NewBuilder().
+			RegisterPlaceholder("renderer", (*Renderer)(nil)).
+			RegisterPlaceholder("logger", (*Renderer)(nil)).
+			Fulfill("logger", mockRenderer("logger")).
+			Build()
+		require.EqualError(t, err, "injector: renderer is a placeholder which isn't fulfilled yet")
+		require.Nil(t, c)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		c, err := NewBuilder().Build()
+		require.NoError(t, err)
+		require.NotNil(t, c)
+	})
+}
@@ -0,0 +1,18 @@
+package injector
+
+const buildInfoName = "build-info"
+
+// BuildInfo contains build metadata of an application.
+// When WithBuildInfo is used, it's registered as "build-info" and can be injected
+// into any field of type BuildInfo via `injector:"auto"`.
+type BuildInfo struct {
+	Version string
+	Commit  string
+}
+
+// WithBuildInfo registers the given build metadata to the Injector.
+func WithBuildInfo(info BuildInfo) Option {
+	return func(c *Injector) {
+		c.buildInfo = &info
+	}
+}
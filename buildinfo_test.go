@@ -0,0 +1,33 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithBuildInfo(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		info := BuildInfo{
+			Version: "v1.0.0",
+			Commit:  "8032e9a",
+		}
+		c := New(WithBuildInfo(info))
+		target := &struct {
+			Auto  BuildInfo `injector:"auto"`
+			Named BuildInfo `injector:"build-info"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, info, target.Auto)
+		require.Equal(t, info, target.Named)
+	})
+
+	t.Run("not-used", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: couldn't find the dependency for injector.BuildInfo", func() {
+			c.Inject(&struct {
+				Info BuildInfo `injector:"auto"`
+			}{})
+		})
+	})
+}
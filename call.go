@@ -0,0 +1,58 @@
+package injector
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"reflect"
+)
+
+// CallMethod invokes the exported method named methodName of obj with its parameters resolved
+// from the Injector by types, e.g. for routing frameworks dispatching to methods of handler objects.
+// Results of the method are returned in order. If the last result is a non-nil error,
+// it's also returned as the error. Variadic methods aren't supported.
+func (c *Injector) CallMethod(obj interface{}, methodName string) ([]interface{}, error) {
+	var results []interface{}
+	err := Run(func() {
+		t := reflect.TypeOf(obj)
+		if t == nil {
+			throw(errors.New("injector: a method can't be called on an untyped nil"))
+		}
+
+		if !token.IsExported(methodName) {
+			throw(fmt.Errorf("injector: %s of %s is unexported and can't be called", methodName, t))
+		}
+
+		method := reflect.ValueOf(obj).MethodByName(methodName)
+		if !method.IsValid() {
+			throw(fmt.Errorf("injector: %s has no method %s", t, methodName))
+		}
+
+		if method.Type().IsVariadic() {
+			throw(errors.New("injector: a non-variadic function is expected"))
+		}
+
+		params, _, err := c.generateInParams(method.Type(), nil)
+		if err != nil {
+			throw(err)
+		}
+
+		out := method.Call(params)
+		results = make([]interface{}, len(out))
+		for i, v := range out {
+			results[i] = v.Interface()
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) > 0 {
+		if resultErr, ok := results[len(results)-1].(error); ok {
+			return results, resultErr
+		}
+	}
+
+	return results, nil
+}
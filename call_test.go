@@ -0,0 +1,70 @@
+package injector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type usersHandler struct {
+	prefix string
+}
+
+func (h *usersHandler) List(r Renderer, n int) (string, int) {
+	return h.prefix + r.Render(), n
+}
+
+func (h *usersHandler) Delete(n int) error {
+	if n > 0 {
+		return errors.New("delete failed")
+	}
+
+	return nil
+}
+
+func (h *usersHandler) Search(terms ...string) {}
+
+func (h *usersHandler) count() int {
+	return 0
+}
+
+func Test_CallMethod(t *testing.T) {
+	c := New()
+	c.NamedComponent("renderer", &rendererImpl{})
+	c.NamedComponent("mocked-int", 10)
+	handler := &usersHandler{prefix: "users-"}
+
+	t.Run("resolved-params", func(t *testing.T) {
+		results, err := c.CallMethod(handler, "List")
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"users-rendered", 10}, results)
+	})
+
+	t.Run("error-result", func(t *testing.T) {
+		results, err := c.CallMethod(handler, "Delete")
+		require.EqualError(t, err, "delete failed")
+		require.Len(t, results, 1)
+	})
+
+	t.Run("missing-method", func(t *testing.T) {
+		_, err := c.CallMethod(handler, "Create")
+		require.EqualError(t, err, "injector: *injector.usersHandler has no method Create")
+	})
+
+	t.Run("unexported-method", func(t *testing.T) {
+		_, err := c.CallMethod(handler, "count")
+		require.EqualError(t, err, "injector: count of *injector.usersHandler is unexported and can't be called")
+		require.Zero(t, handler.count())
+	})
+
+	t.Run("variadic-method", func(t *testing.T) {
+		_, err := c.CallMethod(handler, "Search")
+		require.EqualError(t, err, "injector: a non-variadic function is expected")
+	})
+
+	t.Run("missing-param", func(t *testing.T) {
+		_, err := New().CallMethod(handler, "Delete")
+		require.EqualError(t, err, "injector: couldn't find the dependency for int")
+	})
+}
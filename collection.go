@@ -0,0 +1,169 @@
+package injector
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// collection contains factory functions that each contribute one element to a named slice.
+type collection struct {
+	factories  []interface{}
+	elements   []*dependency
+	assembled  bool
+	assembling bool
+}
+
+// ProvideInto adds a factory function contributing one element to the collection with the given name.
+// It allows multiple modules to extend the same collection, e.g. "handlers", without knowing each other.
+// The collection is injected as a slice into fields tagged with its name, e.g. `injector:"handlers"`,
+// each element must be assignable to the element type of the slice.
+//
+// Factory functions are invoked and their parameters are resolved from the injector
+// only when the collection is requested for the first time, the created elements are then reused.
+// Elements are injected and initialized like other components, e.g. callbacks of WithAfterInject
+// are invoked with names such as "handlers[0]".
+// Hence, dependencies of factory functions can be registered after the collection as long as
+// they're registered before the collection is requested.
+// Get returns the collection as []interface{}.
+func (c *Injector) ProvideInto(name string, factoryFn interface{}) {
+	defer annotatePanic("ProvideInto", name)
+	defer c.recordOperation("ProvideInto", name, factoryFn)()
+
+	c.validateFrozen()
+
+	if fnType := reflect.TypeOf(factoryFn); fnType == nil || fnType.Kind() != reflect.Func {
+		throw(errors.New("injector: a factory function is expected"))
+	}
+
+	col, found := c.collections[name]
+	if !found {
+		c.validateNamne(name)
+		col = &collection{}
+		c.collections[name] = col
+	}
+
+	if col.assembled {
+		throw(fmt.Errorf("injector: %s is already assembled", name))
+	}
+
+	col.factories = append(col.factories, factoryFn)
+	if !found {
+		c.notifyRegistered(name)
+	}
+}
+
+// ProvideSlice declares a collection with all its factory functions at once, in the order of its elements.
+// It's similar to ProvideInto, instead the collection must not be declared before,
+// so its elements are explicitly listed in one place. Factory functions are invoked lazily
+// when the collection is requested for the first time. The collection can still be extended via ProvideInto.
+func (c *Injector) ProvideSlice(name string, factoryFns ...interface{}) {
+	defer annotatePanic("ProvideSlice", name)
+	defer c.recordOperation("ProvideSlice", append([]interface{}{name}, factoryFns...)...)()
+
+	c.validateNamne(name)
+
+	c.collections[name] = &collection{}
+	for _, factoryFn := range factoryFns {
+		c.ProvideInto(name, factoryFn)
+	}
+
+	c.notifyRegistered(name)
+}
+
+// Warmup assembles every collection which isn't assembled yet, so errors of their factory functions
+// surface at a controlled time, e.g. before serving traffic, instead of when collections are first requested.
+// Collections are assembled in the order of their names, those depending on other collections assemble
+// them first. Remaining collections are still assembled if one fails, errors are prefixed with names
+// of failed collections and joined into the returned error.
+func (c *Injector) Warmup() error {
+	names := make([]string, 0, len(c.collections))
+	for name, col := range c.collections {
+		if !col.assembled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	errs := []error{}
+	for _, name := range names {
+		err := Run(func() {
+			if _, err := c.assembleCollection(name, reflectTypeOfInterfaces); err != nil {
+				throw(err)
+			}
+		})
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("injector: collection %s: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Injector) assembleCollection(name string, t reflect.Type) (*dependency, error) {
+	if t.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("injector: %s is a collection, a slice is expected instead of %s", name, t)
+	}
+
+	col := c.collections[name]
+	if !col.assembled {
+		if col.assembling {
+			return nil, fmt.Errorf("injector: %s is a collection depending on itself", name)
+		}
+
+		leave, err := c.enterResolution(name)
+		if err != nil {
+			return nil, err
+		}
+
+		defer leave()
+
+		col.assembling = true
+		defer func() {
+			col.assembling = false
+		}()
+
+		elements := make([]*dependency, 0, len(col.factories))
+		for i, factoryFn := range col.factories {
+			start := c.startTiming()
+			element, err := c.executeFunc(factoryFn, reflect.TypeOf(factoryFn), nil)
+			if err != nil {
+				return nil, err
+			}
+
+			elementName := fmt.Sprintf("%s[%d]", name, i)
+			if err := c.prepare(elementName, element); err != nil {
+				return nil, err
+			}
+
+			c.recordTiming(elementName, start)
+			elements = append(elements, element)
+		}
+
+		col.elements = elements
+		col.assembled = true
+	}
+
+	slice := reflect.MakeSlice(t, 0, len(col.elements))
+	for _, element := range col.elements {
+		if !c.matcher(element.reflectType, t.Elem()) {
+			return nil, fmt.Errorf("injector: %s is not assignable from %s in %s", t.Elem(), element.reflectType, name)
+		}
+
+		v, err := convertValue(element.reflectValue, t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		slice = reflect.Append(slice, v)
+	}
+
+	return &dependency{
+		value:        slice.Interface(),
+		reflectValue: slice,
+		reflectType:  t,
+		dependsOn:    []string{name},
+	}, nil
+}
@@ -0,0 +1,236 @@
+package injector
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProvideInto(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		c := New()
+		created := 0
+		c.NamedComponent("mocked-int", 10)
+		c.ProvideInto("renderers", func() *rendererImpl {
+			created++
+			return &rendererImpl{}
+		})
+		c.ProvideInto("renderers", func(v int) (*TypeA, error) {
+			created++
+			return &TypeA{}, nil
+		})
+		require.Equal(t, 0, created, "factories must be invoked lazily")
+
+		target := &struct {
+			Elements []interface{} `injector:"renderers"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, 2, created)
+		require.Len(t, target.Elements, 2)
+		require.EqualValues(t, 10, target.Elements[1].(*TypeA).Field)
+
+		require.Equal(t, target.Elements, c.Get("renderers"))
+		require.Equal(t, 2, created, "elements must be created once")
+	})
+
+	t.Run("not-assignable", func(t *testing.T) {
+		c := New()
+		c.ProvideInto("renderers", func() *rendererImpl {
+			return &rendererImpl{}
+		})
+		c.ProvideInto("renderers", func() string {
+			return "not-renderer"
+		})
+		require.PanicsWithError(t, "injector: injector.Renderer is not assignable from string in renderers", func() {
+			c.Inject(&struct {
+				Renderers []Renderer `injector:"renderers"`
+			}{})
+		})
+	})
+
+	t.Run("slice-expected", func(t *testing.T) {
+		c := New()
+		c.ProvideInto("renderers", func() *rendererImpl {
+			return &rendererImpl{}
+		})
+		require.PanicsWithError(t, "injector: renderers is a collection, a slice is expected instead of injector.Renderer", func() {
+			c.Inject(&struct {
+				Renderer Renderer `injector:"renderers"`
+			}{})
+		})
+	})
+
+	t.Run("already-assembled", func(t *testing.T) {
+		c := New()
+		c.ProvideInto("renderers", func() *rendererImpl {
+			return &rendererImpl{}
+		})
+		c.Get("renderers")
+		require.PanicsWithError(t, "injector: renderers is already assembled", func() {
+			c.ProvideInto("renderers", func() *rendererImpl {
+				return &rendererImpl{}
+			})
+		})
+	})
+
+	t.Run("name-taken", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("renderers", 10)
+		require.PanicsWithError(t, "injector: renderers is already registered", func() {
+			c.ProvideInto("renderers", func() *rendererImpl {
+				return &rendererImpl{}
+			})
+		})
+	})
+
+	t.Run("invalid-func", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: a factory function is expected", func() {
+			c.ProvideInto("renderers", 10)
+		})
+
+		require.PanicsWithError(t, "injector: a factory function is expected", func() {
+			c.ProvideInto("renderers", nil)
+		})
+	})
+}
+
+func Test_ProvideInto_dependency_registered_later(t *testing.T) {
+	c := New()
+	c.ProvideInto("renderers", func(v int) Renderer {
+		return mockRenderer(fmt.Sprint(v))
+	})
+
+	require.PanicsWithError(t, "injector: couldn't find the dependency for int", func() {
+		c.Get("renderers")
+	})
+
+	c.NamedComponent("mocked-int", 10)
+	require.Equal(t, []interface{}{mockRenderer("10")}, c.Get("renderers"))
+}
+
+func Test_ProvideSlice(t *testing.T) {
+	t.Run("lazy-and-ordered", func(t *testing.T) {
+		c := New()
+		invoked := 0
+		c.ProvideSlice("renderers",
+			func() Renderer {
+				invoked++
+				return mockRenderer("first")
+			},
+			func(v int) Renderer {
+				invoked++
+				return mockRenderer("second")
+			},
+		)
+		c.NamedComponent("mocked-int", 10)
+		require.Zero(t, invoked)
+
+		target := &struct {
+			Renderers []Renderer `injector:"renderers"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, []Renderer{mockRenderer("first"), mockRenderer("second")}, target.Renderers)
+		require.Equal(t, 2, invoked)
+
+		c.Inject(target)
+		require.Equal(t, 2, invoked)
+	})
+
+	t.Run("already-declared", func(t *testing.T) {
+		c := New()
+		c.ProvideInto("renderers", func() Renderer {
+			return mockRenderer("first")
+		})
+		require.PanicsWithError(t, "injector: renderers is already registered", func() {
+			c.ProvideSlice("renderers")
+		})
+	})
+
+	t.Run("invalid-factory", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: a factory function is expected", func() {
+			c.ProvideSlice("renderers", mockRenderer("first"))
+		})
+
+		require.PanicsWithError(t, "injector: a factory function is expected", func() {
+			New().ProvideSlice("renderers", nil)
+		})
+	})
+}
+
+func Test_Warmup(t *testing.T) {
+	t.Run("assembled", func(t *testing.T) {
+		c := New()
+		invoked := 0
+		c.ProvideInto("renderers", func() Renderer {
+			invoked++
+			return mockRenderer("first")
+		})
+		c.ProvideSlice("numbers", func() int {
+			invoked++
+			return 1
+		})
+
+		require.NoError(t, c.Warmup())
+		require.Equal(t, 2, invoked)
+		require.Equal(t, []interface{}{mockRenderer("first")}, c.Get("renderers"))
+		require.NoError(t, c.Warmup())
+		require.Equal(t, 2, invoked)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		c := New()
+		c.ProvideInto("renderers", func(v int) Renderer {
+			return mockRenderer(fmt.Sprint(v))
+		})
+		c.ProvideInto("numbers", func() int {
+			return 1
+		})
+		c.ProvideInto("handlers", func() (Renderer, error) {
+			return nil, errors.New("random error")
+		})
+
+		err := c.Warmup()
+		require.EqualError(t, err, "injector: collection handlers: random error\n"+
+			"injector: collection renderers: injector: couldn't find the dependency for int")
+		require.Equal(t, []interface{}{1}, c.Get("numbers"))
+	})
+
+	t.Run("no-collections", func(t *testing.T) {
+		require.NoError(t, New().Warmup())
+	})
+}
+
+type collectionLoop struct {
+	All []interface{} `injector:"loop"`
+}
+
+func Test_ProvideInto_depending_on_itself(t *testing.T) {
+	c := New()
+	c.ProvideInto("loop", func() *collectionLoop {
+		return &collectionLoop{}
+	})
+
+	err := Run(func() {
+		c.Get("loop")
+	})
+	require.EqualError(t, err, "injector: loop is a collection depending on itself")
+}
+
+func Test_ProvideInto_prepared(t *testing.T) {
+	injected := []string{}
+	c := New(WithAfterInject(func(name string, component interface{}) {
+		injected = append(injected, name)
+	}))
+	c.NamedComponent("mocked-int", 10)
+	c.ProvideInto("components", func() *initWithComponent {
+		return &initWithComponent{}
+	})
+
+	components := c.Get("components").([]interface{})
+	require.Equal(t, 10, components[0].(*initWithComponent).Field)
+	require.Equal(t, []string{"mocked-int", "components[0]"}, injected)
+}
@@ -0,0 +1,78 @@
+package injector
+
+// NewComposite creates a read-only Injector which resolves components from the given injectors in order.
+// A name is resolved from the first injector which has it while a type is resolved across all injectors,
+// it's a conflict if components from different injectors are eligible.
+// It allows composing injectors which are built independently, e.g. by different modules.
+// Registering components to a composite Injector panics.
+func NewComposite(injectors ...*Injector) *Injector {
+	c := New()
+	c.sources = injectors
+	c.readOnly = true
+	return c
+}
+
+// lookup finds a component by name in the Injector, then in its sources and then in its fallback.
+func (c *Injector) lookup(name string) (*dependency, bool) {
+	if dep, found := c.dependencies[name]; found {
+		return dep, true
+	}
+
+	for _, source := range c.sources {
+		if dep, found := source.lookup(name); found {
+			return dep, true
+		}
+	}
+
+	if c.fallback != nil {
+		return c.fallback.lookup(name)
+	}
+
+	return nil, false
+}
+
+// forEach calls fn for every component in the Injector and then in its sources in registration order.
+// Components of sources are skipped if their names are resolved to other components.
+func (c *Injector) forEach(fn func(dep *dependency)) {
+	if len(c.sources) == 0 {
+		for _, name := range c.names {
+			fn(c.dependencies[name])
+		}
+
+		return
+	}
+
+	c.forEachUnique(map[string]bool{}, fn)
+}
+
+func (c *Injector) forEachUnique(visited map[string]bool, fn func(dep *dependency)) {
+	for _, name := range c.names {
+		if !visited[name] {
+			visited[name] = true
+			fn(c.dependencies[name])
+		}
+	}
+
+	for _, source := range c.sources {
+		source.forEachUnique(visited, fn)
+	}
+}
+
+// collectionOwner returns the Injector which owns the named collection or nil if it's not found.
+func (c *Injector) collectionOwner(name string) *Injector {
+	if _, found := c.collections[name]; found {
+		return c
+	}
+
+	for _, source := range c.sources {
+		if owner := source.collectionOwner(name); owner != nil {
+			return owner
+		}
+	}
+
+	if c.fallback != nil {
+		return c.fallback.collectionOwner(name)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package injector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewComposite(t *testing.T) {
+	first := New()
+	first.NamedComponent("mocked-int", 10)
+	first.NamedComponent("shared", "from-first")
+	first.ProvideInto("renderers", func() *rendererImpl {
+		return &rendererImpl{}
+	})
+
+	second := New()
+	second.NamedComponent("shared", "from-second")
+	second.NamedComponent("db-config", &dbConfig{Host: "localhost"})
+
+	c := NewComposite(first, second)
+
+	t.Run("get", func(t *testing.T) {
+		require.EqualValues(t, 10, c.Get("mocked-int"))
+		require.Equal(t, "from-first", c.Get("shared"))
+		require.Equal(t, &dbConfig{Host: "localhost"}, c.Get("db-config"))
+		require.Len(t, c.Get("renderers"), 1)
+		require.Len(t, c.GetByPrefix(""), 3)
+	})
+
+	t.Run("inject", func(t *testing.T) {
+		target := &struct {
+			Field     int           `injector:"auto"`
+			DBConfig  *dbConfig     `injector:"auto"`
+			Shared    string        `injector:"shared"`
+			Renderers []interface{} `injector:"renderers"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, 10, target.Field)
+		require.Equal(t, "localhost", target.DBConfig.Host)
+		require.Equal(t, "from-first", target.Shared)
+		require.Len(t, target.Renderers, 1)
+	})
+
+	t.Run("cross-container-conflict", func(t *testing.T) {
+		second := New()
+		second.NamedComponent("another-int", 11)
+		_, err := NewComposite(first, second).ResolveAuto(reflect.TypeOf(0))
+		require.EqualError(t, err, "injector: there is a conflict when finding the dependency for int: [mocked-int (int), another-int (int)]")
+	})
+
+	t.Run("shadowed-component", func(t *testing.T) {
+		name, err := c.ResolveAuto(reflect.TypeOf(""))
+		require.NoError(t, err, "a shadowed component must not cause conflicts")
+		require.Equal(t, "shared", name)
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		require.PanicsWithError(t, "injector: a composite container is read-only", func() {
+			c.NamedComponent("new-int", 11)
+		})
+		require.PanicsWithError(t, "injector: a composite container is read-only", func() {
+			c.Component(11)
+		})
+	})
+}
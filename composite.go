@@ -0,0 +1,99 @@
+package injector
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// RegisterCombiner registers a combiner function in the form of func([]T) T where T is an interface.
+// A field of type T tagged with `injector:"auto,composite"` receives the result of the combiner
+// invoked with all components matching T, ordered by their names. Types are matched by the matcher
+// set via WithMatcher, assignability by default.
+// It's useful for fan-out patterns, e.g. a Notifier that notifies via all registered Notifiers.
+// The combiner is invoked for every injected field.
+func (c *Injector) RegisterCombiner(combinerFn interface{}) {
+	defer annotatePanic("RegisterCombiner", "")
+	defer c.recordOperation("RegisterCombiner", combinerFn)()
+
+	c.validateFrozen()
+
+	fnType := reflect.TypeOf(combinerFn)
+	if fnType == nil || fnType.Kind() != reflect.Func ||
+		fnType.NumIn() != 1 || fnType.NumOut() != 1 ||
+		fnType.In(0).Kind() != reflect.Slice || fnType.In(0).Elem() != fnType.Out(0) {
+		throw(errors.New("injector: a combiner function in the form of func([]T) T is expected"))
+	}
+
+	t := fnType.Out(0)
+	if _, found := c.combiners[t]; found {
+		throw(fmt.Errorf("injector: a combiner for %s is already registered", t))
+	}
+
+	c.combiners[t] = reflect.ValueOf(combinerFn)
+}
+
+func (c *Injector) combine(t reflect.Type) (*dependency, error) {
+	if c.noAutoInjection {
+		return nil, errNoAutoInjection(t)
+	}
+
+	if t.Kind() != reflect.Interface {
+		return nil, fmt.Errorf("injector: %s is not injectable as a composite, an interface is expected", t)
+	}
+
+	combiner, found := c.findCombiner(t)
+	if !found {
+		return nil, fmt.Errorf("injector: no combiner is registered for %s", t)
+	}
+
+	deps := []*dependency{}
+	c.forEach(func(dep *dependency) {
+		if c.matcher(dep.reflectType, t) {
+			deps = append(deps, dep)
+		}
+	})
+	sort.Slice(deps, func(i, j int) bool {
+		return deps[i].name < deps[j].name
+	})
+
+	names := make([]string, 0, len(deps))
+	components := reflect.MakeSlice(reflect.SliceOf(t), 0, len(deps))
+	for _, dep := range deps {
+		dep, err := c.resolve(dep.name, dep)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := convertValue(dep.reflectValue, t)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, dep.name)
+		components = reflect.Append(components, v)
+	}
+
+	result := combiner.Call([]reflect.Value{components})[0]
+	return &dependency{
+		value:        result.Interface(),
+		reflectValue: result,
+		reflectType:  t,
+		dependsOn:    names,
+	}, nil
+}
+
+func (c *Injector) findCombiner(t reflect.Type) (reflect.Value, bool) {
+	if combiner, found := c.combiners[t]; found {
+		return combiner, true
+	}
+
+	for _, source := range c.sources {
+		if combiner, found := source.findCombiner(t); found {
+			return combiner, true
+		}
+	}
+
+	return reflect.Value{}, false
+}
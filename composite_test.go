@@ -0,0 +1,79 @@
+package injector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockRenderer string
+
+func (r mockRenderer) Render() string {
+	return string(r)
+}
+
+type multiRenderer []Renderer
+
+func (m multiRenderer) Render() string {
+	rendered := []string{}
+	for _, r := range m {
+		rendered = append(rendered, r.Render())
+	}
+
+	return strings.Join(rendered, ",")
+}
+
+func combineRenderers(renderers []Renderer) Renderer {
+	return multiRenderer(renderers)
+}
+
+func Test_RegisterCombiner(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		c := New()
+		c.RegisterCombiner(combineRenderers)
+		c.NamedComponent("renderer-b", mockRenderer("b"))
+		c.NamedComponent("renderer-a", mockRenderer("a"))
+		target := &struct {
+			Renderer Renderer `injector:"auto,composite"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, "a,b", target.Renderer.Render())
+	})
+
+	t.Run("invalid-combiner", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: a combiner function in the form of func([]T) T is expected", func() {
+			c.RegisterCombiner(func(renderers []Renderer) string {
+				return ""
+			})
+		})
+	})
+
+	t.Run("duplicate-combiner", func(t *testing.T) {
+		c := New()
+		c.RegisterCombiner(combineRenderers)
+		require.PanicsWithError(t, "injector: a combiner for injector.Renderer is already registered", func() {
+			c.RegisterCombiner(combineRenderers)
+		})
+	})
+
+	t.Run("missing-combiner", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("renderer-a", mockRenderer("a"))
+		require.PanicsWithError(t, "injector: no combiner is registered for injector.Renderer", func() {
+			c.Inject(&struct {
+				Renderer Renderer `injector:"auto,composite"`
+			}{})
+		})
+	})
+
+	t.Run("not-interface", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: int is not injectable as a composite, an interface is expected", func() {
+			c.Inject(&struct {
+				Field int `injector:"auto,composite"`
+			}{})
+		})
+	})
+}
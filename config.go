@@ -0,0 +1,162 @@
+package injector
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BindConfig fills exported fields of target, a pointer to a struct, from environment variables
+// and registers target as a component with a generated name, so it can be injected by type.
+// The variable of a field is named "<PREFIX>_<FIELD NAME>" in upper case, e.g. DB_HOST for the field Host
+// with the prefix "db", or only the upper-cased field name if prefix is empty.
+// Fields whose variables aren't set keep their values, so defaults can be set in target beforehand.
+// Values are parsed into types of fields, which can be strings, booleans, numbers, time.Duration
+// or types implementing encoding.TextUnmarshaler. An error is returned if a value can't be parsed.
+func (c *Injector) BindConfig(prefix string, target interface{}) error {
+	return Run(func() {
+		t := reflect.TypeOf(target)
+		if t == nil || !isStructPtr(t) {
+			throw(fmt.Errorf("injector: %v can't be bound to a config, a pointer to a struct is expected", t))
+		}
+
+		v := reflect.ValueOf(target).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			structField := t.Elem().Field(i)
+			if !structField.IsExported() {
+				continue
+			}
+
+			envName := strings.ToUpper(structField.Name)
+			if prefix != "" {
+				envName = strings.ToUpper(prefix) + "_" + envName
+			}
+
+			text, found := os.LookupEnv(envName)
+			if !found {
+				continue
+			}
+
+			if err := parseText(text, v.Field(i)); err != nil {
+				throw(fmt.Errorf("injector: %s can't be parsed into %s of %s: %w", envName, structField.Name, t.Elem(), err))
+			}
+		}
+
+		c.Component(target)
+	})
+}
+
+// parseText parses text into v, which must be settable.
+func parseText(text string, v reflect.Value) error {
+	if reflect.PointerTo(v.Type()).Implements(reflectTypeOfTextUnmarshaler) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+	}
+
+	if v.Type() == reflectTypeOfDuration {
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("%s isn't parsable", v.Type())
+	}
+
+	return nil
+}
+
+// loadConfig creates a struct of type t, or a pointer to it, whose exported fields are loaded
+// from components named "<prefix>.<field name>". It's used for fields tagged with `injector:"config,prefix=db"`.
+// Fields whose components aren't registered are left at their zero values if optional is true.
+func (c *Injector) loadConfig(prefix string, optional bool, t reflect.Type) (*dependency, error) {
+	structType := t
+	if t.Kind() == reflect.Ptr {
+		structType = t.Elem()
+	}
+
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("injector: %s is not injectable as a config, a struct is expected", t)
+	}
+
+	ptr := reflect.New(structType)
+	dependsOn := []string{}
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		name := prefix + "." + structField.Name
+		dep, found := c.lookup(name)
+		if !found && optional {
+			c.debug("injector: skipped missing optional config field", "field", structField.Name, "name", name)
+			continue
+		}
+
+		if !found {
+			return nil, errNotFound("injector: %s is not registered", name)
+		}
+
+		dep, err := c.resolve(name, dep)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.assignField(ptr.Elem().Field(i), dep); err != nil {
+			return nil, err
+		}
+
+		dependsOn = append(dependsOn, name)
+	}
+
+	config := ptr
+	if t.Kind() != reflect.Ptr {
+		config = ptr.Elem()
+	}
+
+	return &dependency{
+		value:        config.Interface(),
+		reflectValue: config,
+		reflectType:  t,
+		dependsOn:    dependsOn,
+	}, nil
+}
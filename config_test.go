@@ -0,0 +1,152 @@
+package injector
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type dbConfig struct {
+	Host     string
+	Port     int
+	internal bool
+}
+
+func Test_loadConfig(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("db.Host", "localhost")
+		c.NamedComponent("db.Port", 5432)
+		target := &struct {
+			Config    dbConfig  `injector:"config,prefix=db"`
+			ConfigPtr *dbConfig `injector:"config,prefix=db"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, dbConfig{Host: "localhost", Port: 5432}, target.Config)
+		require.Equal(t, &dbConfig{Host: "localhost", Port: 5432}, target.ConfigPtr)
+	})
+
+	t.Run("missing-field", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("db.Host", "localhost")
+		require.PanicsWithError(t, "injector: db.Port is not registered", func() {
+			c.Inject(&struct {
+				Config dbConfig `injector:"config,prefix=db"`
+			}{})
+		})
+	})
+
+	t.Run("optional-field", func(t *testing.T) {
+		c := New(WithOptionalByDefault())
+		c.NamedComponent("db.Host", "localhost")
+		target := &struct {
+			Config dbConfig `injector:"config,prefix=db"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, dbConfig{Host: "localhost"}, target.Config)
+
+		require.PanicsWithError(t, "injector: db.Port is not registered", func() {
+			c.Inject(&struct {
+				Config dbConfig `injector:"config,prefix=db,required"`
+			}{})
+		})
+	})
+
+	t.Run("not-assignable", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("db.Host", "localhost")
+		c.NamedComponent("db.Port", "5432")
+		require.PanicsWithError(t, "injector: int is not assignable from string", func() {
+			c.Inject(&struct {
+				Config dbConfig `injector:"config,prefix=db"`
+			}{})
+		})
+	})
+
+	t.Run("not-struct", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: string is not injectable as a config, a struct is expected", func() {
+			c.Inject(&struct {
+				Config string `injector:"config,prefix=db"`
+			}{})
+		})
+	})
+
+	t.Run("named-config", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("config", "app-config")
+		target := &struct {
+			Config string `injector:"config"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, "app-config", target.Config)
+	})
+}
+
+type envConfig struct {
+	Host    string
+	Port    int
+	Debug   bool
+	Ratio   float64
+	Timeout time.Duration
+	Addr    net.IP
+	skipped string
+}
+
+func Test_BindConfig(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		t.Setenv("SERVER_HOST", "localhost")
+		t.Setenv("SERVER_PORT", "8080")
+		t.Setenv("SERVER_DEBUG", "true")
+		t.Setenv("SERVER_RATIO", "0.5")
+		t.Setenv("SERVER_TIMEOUT", "3s")
+		t.Setenv("SERVER_ADDR", "127.0.0.1")
+		t.Setenv("SERVER_SKIPPED", "skipped")
+
+		c := New()
+		config := &envConfig{}
+		require.NoError(t, c.BindConfig("server", config))
+		require.Equal(t, &envConfig{
+			Host:    "localhost",
+			Port:    8080,
+			Debug:   true,
+			Ratio:   0.5,
+			Timeout: 3 * time.Second,
+			Addr:    net.ParseIP("127.0.0.1"),
+		}, config)
+		require.Same(t, config, GetByType[*envConfig](c))
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Setenv("PORT", "9090")
+
+		c := New()
+		config := &envConfig{Host: "0.0.0.0", Port: 80}
+		require.NoError(t, c.BindConfig("", config))
+		require.Equal(t, "0.0.0.0", config.Host)
+		require.Equal(t, 9090, config.Port)
+	})
+
+	t.Run("unparsable", func(t *testing.T) {
+		t.Setenv("SERVER_PORT", "http")
+
+		c := New()
+		err := c.BindConfig("server", &envConfig{})
+		require.EqualError(t, err, `injector: SERVER_PORT can't be parsed into Port of injector.envConfig: strconv.ParseInt: parsing "http": invalid syntax`)
+		require.Empty(t, ComponentsOfType[*envConfig](c))
+	})
+
+	t.Run("not-parsable-type", func(t *testing.T) {
+		t.Setenv("ITEMS", "a,b")
+
+		err := New().BindConfig("", &struct{ Items []string }{})
+		require.EqualError(t, err, "injector: ITEMS can't be parsed into Items of struct { Items []string }: []string isn't parsable")
+	})
+
+	t.Run("not-struct-pointer", func(t *testing.T) {
+		err := New().BindConfig("server", envConfig{})
+		require.EqualError(t, err, "injector: injector.envConfig can't be bound to a config, a pointer to a struct is expected")
+	})
+}
@@ -0,0 +1,18 @@
+package injector
+
+import (
+	"context"
+)
+
+const contextName = "context"
+
+// WithContext registers the given context to the Injector as "context". It's registered as
+// context.Context, so it can be injected into any field or factory parameter of type context.Context
+// via `injector:"auto"` without conflicting with other interfaces the context implements.
+// The context is registered once when the Injector is created, a context derived later,
+// e.g. with a deadline, isn't seen by the Injector and has to be registered under another name.
+func WithContext(ctx context.Context) Option {
+	return func(c *Injector) {
+		c.ctx = ctx
+	}
+}
@@ -0,0 +1,55 @@
+package injector
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type contextKey string
+
+type stringerImpl struct{}
+
+func (stringerImpl) String() string {
+	return "stringer"
+}
+
+func Test_WithContext(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), contextKey("key"), "value")
+		c := New(WithContext(ctx))
+		target := &struct {
+			Auto  context.Context `injector:"auto"`
+			Named context.Context `injector:"context"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, ctx, target.Auto)
+		require.Equal(t, ctx, target.Named)
+
+		name := c.ComponentFromFunc(func(ctx context.Context) string {
+			return ctx.Value(contextKey("key")).(string)
+		})
+		require.Equal(t, "value", c.Get(name))
+	})
+
+	t.Run("no-conflict-with-other-interfaces", func(t *testing.T) {
+		c := New(WithContext(context.Background()))
+		c.NamedComponent("stringer", stringerImpl{})
+		target := &struct {
+			Stringer fmt.Stringer `injector:"auto"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, "stringer", target.Stringer.String())
+	})
+
+	t.Run("not-used", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: couldn't find the dependency for context.Context", func() {
+			c.Inject(&struct {
+				Ctx context.Context `injector:"auto"`
+			}{})
+		})
+	})
+}
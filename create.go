@@ -0,0 +1,42 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// createStruct creates a new struct for a field tagged with the create option and injects dependencies into it.
+// The created struct isn't registered, so a new one is created for every field.
+func (c *Injector) createStruct(t reflect.Type) (*dependency, error) {
+	if !isStructPtr(t) {
+		return nil, fmt.Errorf("injector: %s can't be created, a pointer to a struct is expected", t)
+	}
+
+	for _, name := range c.resolving {
+		if name == t.String() {
+			chain := strings.Join(append(c.resolving, name), " -> ")
+			return nil, fmt.Errorf("injector: %s is created recursively: %s", t, chain)
+		}
+	}
+
+	leave, err := c.enterResolution(t.String())
+	if err != nil {
+		return nil, err
+	}
+
+	defer leave()
+
+	v := reflect.New(t.Elem())
+	created := &dependency{
+		value:        v.Interface(),
+		reflectValue: v,
+		reflectType:  t,
+	}
+
+	if err := c.prepare(t.String(), created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
@@ -0,0 +1,69 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type handler struct {
+	Service *service `injector:"auto,create"`
+}
+
+type service struct {
+	Repo  *TypeA `injector:"auto,create"`
+	Field int    `injector:"mocked-int"`
+}
+
+type cyclicA struct {
+	B *cyclicB `injector:"auto,create"`
+}
+
+type cyclicB struct {
+	A *cyclicA `injector:"auto,create"`
+}
+
+func Test_Inject_create(t *testing.T) {
+	t.Run("created", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("handler", &handler{})
+
+		h := c.Get("handler").(*handler)
+		require.Equal(t, 10, h.Service.Field)
+		require.Equal(t, 10, h.Service.Repo.Field)
+		require.Equal(t, []string{"handler"}, c.Dependents("mocked-int"))
+	})
+
+	t.Run("registered-first", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		registered := &service{}
+		c.NamedComponent("service", registered)
+		c.NamedComponent("handler", &handler{})
+		require.Same(t, registered, c.Get("handler").(*handler).Service)
+	})
+
+	t.Run("unsatisfiable", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: mocked-int is not registered", func() {
+			c.Inject(&handler{})
+		})
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: *injector.cyclicB is created recursively: *injector.cyclicB -> *injector.cyclicA -> *injector.cyclicB", func() {
+			c.Inject(&cyclicA{})
+		})
+	})
+
+	t.Run("not-struct", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: int can't be created, a pointer to a struct is expected", func() {
+			c.Inject(&struct {
+				Field int `injector:"auto,create"`
+			}{})
+		})
+	})
+}
@@ -0,0 +1,77 @@
+package injector
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RegisterDefaults registers default values from exported fields of a struct or a pointer to a struct.
+// When a dependency of a tagged field isn't registered, by name or by type, the default value
+// whose field name matches the name of the tagged field is injected instead, e.g. a Timeout field
+// of defaults is used for any tagged field named Timeout. Names are matched case-insensitively
+// unless WithCaseSensitiveDefaults is used. It centralizes default values of optional dependencies.
+func (c *Injector) RegisterDefaults(defaults interface{}) {
+	defer annotatePanic("RegisterDefaults", "")
+	defer c.recordOperation("RegisterDefaults", defaults)()
+
+	c.validateFrozen()
+
+	v := reflect.ValueOf(defaults)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		throw(errors.New("injector: a struct or a pointer to a struct is expected for defaults"))
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		structField := v.Type().Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+
+		key := c.defaultKey(structField.Name)
+		if _, found := c.defaults[key]; found {
+			throw(fmt.Errorf("injector: a default for %s is already registered", structField.Name))
+		}
+
+		c.defaults[key] = &dependency{
+			value:        v.Field(i).Interface(),
+			reflectValue: v.Field(i),
+			reflectType:  structField.Type,
+		}
+	}
+}
+
+// WithCaseSensitiveDefaults matches names of fields with names of default values case-sensitively.
+func WithCaseSensitiveDefaults() Option {
+	return func(c *Injector) {
+		c.caseSensitive = true
+	}
+}
+
+// lookupDefault finds the default value for a field in the Injector and then in its sources.
+func (c *Injector) lookupDefault(fieldName string) (*dependency, bool) {
+	if dep, found := c.defaults[c.defaultKey(fieldName)]; found {
+		return dep, true
+	}
+
+	for _, source := range c.sources {
+		if dep, found := source.lookupDefault(fieldName); found {
+			return dep, true
+		}
+	}
+
+	return nil, false
+}
+
+func (c *Injector) defaultKey(fieldName string) string {
+	if c.caseSensitive {
+		return fieldName
+	}
+
+	return strings.ToLower(fieldName)
+}
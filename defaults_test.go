@@ -0,0 +1,94 @@
+package injector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type serverDefaults struct {
+	Timeout  time.Duration
+	Host     string
+	internal int
+}
+
+type serverConfig struct {
+	Timeout time.Duration `injector:"timeout"`
+	HOST    string        `injector:"auto"`
+	Port    int           `injector:"port"`
+}
+
+func Test_RegisterDefaults(t *testing.T) {
+	t.Run("missing-dependencies", func(t *testing.T) {
+		c := New()
+		c.RegisterDefaults(serverDefaults{Timeout: time.Second, Host: "localhost"})
+		c.NamedComponent("port", 8080)
+
+		target := &serverConfig{}
+		c.Inject(target)
+		require.Equal(t, serverConfig{Timeout: time.Second, HOST: "localhost", Port: 8080}, *target)
+	})
+
+	t.Run("registered-dependencies-first", func(t *testing.T) {
+		c := New()
+		c.RegisterDefaults(&serverDefaults{Timeout: time.Second, Host: "localhost"})
+		c.NamedComponent("port", 8080)
+		c.NamedComponent("timeout", time.Minute)
+		c.NamedComponent("host", "example.com")
+
+		target := &serverConfig{}
+		c.Inject(target)
+		require.Equal(t, serverConfig{Timeout: time.Minute, HOST: "example.com", Port: 8080}, *target)
+	})
+
+	t.Run("no-default", func(t *testing.T) {
+		c := New()
+		c.RegisterDefaults(serverDefaults{Timeout: time.Second, Host: "localhost"})
+		require.PanicsWithError(t, "injector: port is not registered", func() {
+			c.Inject(&serverConfig{})
+		})
+	})
+
+	t.Run("conflict-isnt-defaulted", func(t *testing.T) {
+		c := New()
+		c.RegisterDefaults(serverDefaults{Host: "localhost"})
+		c.NamedComponent("host-1", "1")
+		c.NamedComponent("host-2", "2")
+		require.PanicsWithError(t, "injector: there is a conflict when finding the dependency for string: [host-1 (string), host-2 (string)]", func() {
+			c.Inject(&struct {
+				Host string `injector:"auto"`
+			}{})
+		})
+	})
+
+	t.Run("case-sensitive", func(t *testing.T) {
+		c := New(WithCaseSensitiveDefaults())
+		c.RegisterDefaults(serverDefaults{Timeout: time.Second, Host: "localhost"})
+		c.NamedComponent("port", 8080)
+		require.PanicsWithError(t, "injector: couldn't find the dependency for string", func() {
+			c.Inject(&serverConfig{})
+		})
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		c := New(WithOptionalByDefault())
+		c.RegisterDefaults(serverDefaults{Timeout: time.Second, Host: "localhost"})
+
+		target := &serverConfig{}
+		c.Inject(target)
+		require.Equal(t, serverConfig{Timeout: time.Second, HOST: "localhost"}, *target)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		c := New()
+		c.RegisterDefaults(serverDefaults{})
+		require.PanicsWithError(t, "injector: a default for Timeout is already registered", func() {
+			c.RegisterDefaults(serverDefaults{})
+		})
+
+		require.PanicsWithError(t, "injector: a struct or a pointer to a struct is expected for defaults", func() {
+			c.RegisterDefaults(10)
+		})
+	})
+}
@@ -0,0 +1,32 @@
+package injector
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithMaxDepth limits how deep components can be created recursively while resolving dependencies,
+// e.g. a prototype depending on another prototype or a collection whose elements inject other collections.
+// An error listing the chain of components being resolved is returned if the limit is exceeded.
+// It guards against runaway construction. There is no limit by default.
+func WithMaxDepth(n int) Option {
+	return func(c *Injector) {
+		c.maxDepth = n
+	}
+}
+
+// enterResolution marks the named component as being created and returns a function to unmark it.
+func (c *Injector) enterResolution(name string) (func(), error) {
+	c.resolving = append(c.resolving, name)
+	leave := func() {
+		c.resolving = c.resolving[:len(c.resolving)-1]
+	}
+
+	if c.maxDepth > 0 && len(c.resolving) > c.maxDepth {
+		chain := strings.Join(c.resolving, " -> ")
+		leave()
+		return nil, fmt.Errorf("injector: the resolution depth exceeds %d: %s", c.maxDepth, chain)
+	}
+
+	return leave, nil
+}
@@ -0,0 +1,54 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	layerA struct{}
+	layerB struct{}
+	layerC struct{}
+)
+
+func registerLayers(c *Injector) {
+	c.NamedPrototypeFromFunc("layer-a", func(b *layerB) *layerA {
+		return &layerA{}
+	})
+	c.NamedPrototypeFromFunc("layer-b", func(c *layerC) *layerB {
+		return &layerB{}
+	})
+	c.NamedPrototypeFromFunc("layer-c", func() *layerC {
+		return &layerC{}
+	})
+}
+
+func Test_WithMaxDepth(t *testing.T) {
+	t.Run("exceeded", func(t *testing.T) {
+		c := New(WithMaxDepth(2))
+		registerLayers(c)
+		require.PanicsWithError(t, "injector: the resolution depth exceeds 2: layer-a -> layer-b -> layer-c", func() {
+			c.Get("layer-a")
+		})
+
+		require.NotNil(t, c.Get("layer-b"))
+	})
+
+	t.Run("collection", func(t *testing.T) {
+		c := New(WithMaxDepth(1))
+		registerLayers(c)
+		c.ProvideInto("layers", func(b *layerB) interface{} {
+			return b
+		})
+		require.PanicsWithError(t, "injector: the resolution depth exceeds 1: layers -> layer-b", func() {
+			c.Get("layers")
+		})
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		c := New()
+		registerLayers(c)
+		require.NotNil(t, c.Get("layer-a"))
+	})
+}
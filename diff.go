@@ -0,0 +1,51 @@
+package injector
+
+import (
+	"sort"
+)
+
+// Diff describes differences between components of two injectors, names are sorted.
+type Diff struct {
+	// OnlyInA contains names of components which are only registered to the first injector.
+	OnlyInA []string
+	// OnlyInB contains names of components which are only registered to the second injector.
+	OnlyInB []string
+	// TypeChanged contains names of components which are registered to both injectors with different types.
+	TypeChanged []string
+}
+
+// DiffContainers compares components registered to two injectors by names and types,
+// including components of sources if an injector is a composite. Collections aren't compared.
+// It's useful in tests to assert that an injector has exactly the expected components
+// compared to a base one.
+func DiffContainers(a, b *Injector) Diff {
+	depsOfB := map[string]*dependency{}
+	b.forEach(func(dep *dependency) {
+		depsOfB[dep.name] = dep
+	})
+
+	diff := Diff{
+		OnlyInA:     []string{},
+		OnlyInB:     []string{},
+		TypeChanged: []string{},
+	}
+	a.forEach(func(dep *dependency) {
+		depOfB, found := depsOfB[dep.name]
+		delete(depsOfB, dep.name)
+		switch {
+		case !found:
+			diff.OnlyInA = append(diff.OnlyInA, dep.name)
+		case depOfB.reflectType != dep.reflectType:
+			diff.TypeChanged = append(diff.TypeChanged, dep.name)
+		}
+	})
+
+	for name := range depsOfB {
+		diff.OnlyInB = append(diff.OnlyInB, name)
+	}
+
+	sort.Strings(diff.OnlyInA)
+	sort.Strings(diff.OnlyInB)
+	sort.Strings(diff.TypeChanged)
+	return diff
+}
@@ -0,0 +1,47 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DiffContainers(t *testing.T) {
+	a := New()
+	a.NamedComponent("mocked-int", 10)
+	a.NamedComponent("only-a", "a")
+	a.NamedComponent("changed", 1)
+
+	b := New()
+	b.NamedComponent("mocked-int", 20)
+	b.NamedComponent("only-b-2", "b")
+	b.NamedComponent("only-b-1", "b")
+	b.NamedComponent("changed", "1")
+
+	t.Run("different", func(t *testing.T) {
+		require.Equal(t, Diff{
+			OnlyInA:     []string{"only-a"},
+			OnlyInB:     []string{"only-b-1", "only-b-2"},
+			TypeChanged: []string{"changed"},
+		}, DiffContainers(a, b))
+	})
+
+	t.Run("same", func(t *testing.T) {
+		require.Equal(t, Diff{
+			OnlyInA:     []string{},
+			OnlyInB:     []string{},
+			TypeChanged: []string{},
+		}, DiffContainers(a, a))
+	})
+
+	t.Run("composite", func(t *testing.T) {
+		extra := New()
+		extra.NamedComponent("extra", true)
+
+		require.Equal(t, Diff{
+			OnlyInA:     []string{},
+			OnlyInB:     []string{"extra"},
+			TypeChanged: []string{},
+		}, DiffContainers(a, NewComposite(a, extra)))
+	})
+}
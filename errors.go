@@ -0,0 +1,67 @@
+package injector
+
+// PanicError is the value used when the injector panics. Besides the original error, it contains
+// the operation, i.e. the name of the method called on the Injector, and the name of the component involved
+// if any, so callers recovering the panic can handle it without parsing messages.
+// Its message is the message of the original error.
+type PanicError struct {
+	// Op is the name of the method which panicked, e.g. "NamedComponent".
+	Op string
+	// Name is the name of the component involved, it's empty if the operation doesn't involve a named component.
+	Name string
+	// Err is the original error.
+	Err error
+}
+
+func (p *PanicError) Error() string {
+	return p.Err.Error()
+}
+
+func (p *PanicError) Unwrap() error {
+	return p.Err
+}
+
+func throw(err error) {
+	panic(&PanicError{Err: err})
+}
+
+// annotatePanic adds the operation and the component name to a PanicError being raised.
+// It must be deferred directly. The outermost operation takes precedence as it's the one called by users,
+// an empty name doesn't override the name set by an inner operation.
+func annotatePanic(op, name string) {
+	if r := recover(); r != nil {
+		if p, ok := r.(*PanicError); ok {
+			p.Op = op
+			if name != "" {
+				p.Name = name
+			}
+		}
+
+		panic(r)
+	}
+}
+
+// Run executes fn and recovers any panic raised by the injector into the returned error.
+// It's handy to wrap the whole wiring block of an application and handle errors in one place:
+//
+//	err := injector.Run(func() {
+//	  c.Component(&ServiceAImpl{})
+//	  c.Component(&ServiceBImpl{})
+//	})
+//
+// The returned error is a *PanicError. Panics which aren't raised by the injector are propagated.
+func Run(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			p, ok := r.(*PanicError)
+			if !ok {
+				panic(r)
+			}
+
+			err = p
+		}
+	}()
+
+	fn()
+	return nil
+}
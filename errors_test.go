@@ -0,0 +1,78 @@
+package injector
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Run(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		c := New()
+		err := Run(func() {
+			c.NamedComponent("mocked-int", 10)
+			c.NamedComponent("type-a", &TypeA{})
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("injector-panic", func(t *testing.T) {
+		c := New()
+		err := Run(func() {
+			c.NamedComponent("type-a", &TypeA{})
+		})
+		require.EqualError(t, err, "injector: mocked-int is not registered")
+	})
+
+	t.Run("other-panic", func(t *testing.T) {
+		require.PanicsWithValue(t, "random panic", func() {
+			_ = Run(func() {
+				panic("random panic")
+			})
+		})
+	})
+}
+
+func Test_PanicError(t *testing.T) {
+	t.Run("named-operation", func(t *testing.T) {
+		c := New()
+		err := Run(func() {
+			c.NamedComponent("type-a", &TypeA{})
+		})
+
+		panicErr, ok := err.(*PanicError)
+		require.True(t, ok)
+		require.Equal(t, "NamedComponent", panicErr.Op)
+		require.Equal(t, "type-a", panicErr.Name)
+		require.EqualError(t, panicErr.Err, "injector: mocked-int is not registered")
+	})
+
+	t.Run("outermost-operation", func(t *testing.T) {
+		c := New()
+		var name string
+		err := Run(func() {
+			name = c.nextGeneratedName(reflect.TypeOf(&TypeA{}))
+			c.Component(&TypeA{})
+		})
+
+		panicErr, ok := err.(*PanicError)
+		require.True(t, ok)
+		require.Equal(t, "Component", panicErr.Op)
+		require.Equal(t, name, panicErr.Name)
+	})
+
+	t.Run("recovered", func(t *testing.T) {
+		c := New()
+		defer func() {
+			panicErr, ok := recover().(*PanicError)
+			require.True(t, ok)
+			require.Equal(t, "Inject", panicErr.Op)
+			require.Empty(t, panicErr.Name)
+			require.True(t, errors.Is(panicErr, panicErr.Err))
+		}()
+
+		c.Inject(&TypeA{})
+	})
+}
@@ -0,0 +1,16 @@
+package injector
+
+// OnRegister subscribes fn to registrations of the Injector, fn is invoked with the name of every component,
+// placeholder, prototype or collection registered afterwards, once it's ready to be loaded.
+// It enables reactive systems to re-validate or re-wire when components are registered late,
+// e.g. by plugins loaded dynamically. Subscribers are invoked synchronously in the order of subscription,
+// they may load or register components, a panic of a subscriber fails the registration.
+func (c *Injector) OnRegister(fn func(name string)) {
+	c.onRegisters = append(c.onRegisters, fn)
+}
+
+func (c *Injector) notifyRegistered(name string) {
+	for _, fn := range c.onRegisters {
+		fn(name)
+	}
+}
@@ -0,0 +1,59 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_OnRegister(t *testing.T) {
+	t.Run("notified", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("before", 1)
+
+		registered := []string{}
+		c.OnRegister(func(name string) {
+			registered = append(registered, name)
+		})
+
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponentFromFunc("renderer", func() Renderer { return &rendererImpl{} })
+		c.RegisterPlaceholder("placeholder", (*Renderer)(nil))
+		c.NamedPrototypeFromFunc("prototype", func() *TypeA { return &TypeA{} })
+		c.ProvideInto("collection", func() int { return 1 })
+		c.ProvideInto("collection", func() int { return 2 })
+		c.ProvideSlice("slice", func() int { return 1 })
+
+		require.Equal(t, []string{"mocked-int", "renderer", "placeholder", "prototype", "collection", "slice"}, registered)
+	})
+
+	t.Run("re-wiring", func(t *testing.T) {
+		c := New()
+		target := &struct {
+			Renderer Renderer `injector:"auto"`
+		}{}
+
+		c.OnRegister(func(name string) {
+			if c.IsAmbiguous(reflectTypeOfRenderer) {
+				return
+			}
+
+			c.Inject(target)
+		})
+
+		c.NamedComponent("renderer", &rendererImpl{})
+		require.Equal(t, "rendered", target.Renderer.Render())
+	})
+
+	t.Run("registering-in-callback", func(t *testing.T) {
+		c := New()
+		c.OnRegister(func(name string) {
+			if name == "mocked-int" {
+				c.NamedComponent("mocked-string", "registered")
+			}
+		})
+
+		c.NamedComponent("mocked-int", 10)
+		require.Equal(t, "registered", c.Get("mocked-string"))
+	})
+}
@@ -0,0 +1,40 @@
+package injector
+
+import "errors"
+
+// SetFallback sets the Injector to which resolution is delegated when a name or a type isn't found locally.
+// Unlike NewComposite, the fallback can be attached after the Injector is created and swapped later,
+// e.g. to layer a plugin container over a host container at runtime. Local components always take
+// precedence over components of the fallback. A nil fallback detaches the current one.
+// It panics if other delegates to the Injector, directly or indirectly via fallbacks or sources of composites,
+// as it results in a cycle.
+func (c *Injector) SetFallback(other *Injector) {
+	defer annotatePanic("SetFallback", "")
+
+	if other != nil && other.delegatesTo(c, map[*Injector]bool{}) {
+		throw(errors.New("injector: setting the fallback results in a cycle"))
+	}
+
+	c.fallback = other
+}
+
+// delegatesTo returns true if the Injector is target or delegates resolution to target,
+// directly or indirectly, via its sources or its fallback.
+func (c *Injector) delegatesTo(target *Injector, visited map[*Injector]bool) bool {
+	if c == target {
+		return true
+	}
+
+	if visited[c] {
+		return false
+	}
+
+	visited[c] = true
+	for _, source := range c.sources {
+		if source.delegatesTo(target, visited) {
+			return true
+		}
+	}
+
+	return c.fallback != nil && c.fallback.delegatesTo(target, visited)
+}
@@ -0,0 +1,94 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SetFallback(t *testing.T) {
+	newContainers := func() (*Injector, *Injector) {
+		host := New()
+		host.NamedComponent("mocked-int", 10)
+		host.NamedComponent("renderer", &rendererImpl{})
+
+		plugin := New()
+		plugin.NamedComponent("mocked-int", 20)
+		plugin.SetFallback(host)
+		return plugin, host
+	}
+
+	t.Run("local-hit", func(t *testing.T) {
+		plugin, _ := newContainers()
+		require.Equal(t, 20, plugin.Get("mocked-int"))
+
+		target := &struct {
+			Number int `injector:"auto"`
+		}{}
+		plugin.Inject(target)
+		require.Equal(t, 20, target.Number)
+	})
+
+	t.Run("fallback-hit", func(t *testing.T) {
+		plugin, host := newContainers()
+		require.Same(t, host.Get("renderer"), plugin.Get("renderer"))
+
+		target := &struct {
+			Renderer Renderer `injector:"auto"`
+			Named    Renderer `injector:"renderer"`
+		}{}
+		plugin.Inject(target)
+		require.Equal(t, "rendered", target.Renderer.Render())
+		require.Equal(t, "rendered", target.Named.Render())
+	})
+
+	t.Run("fallback-hit-by-key", func(t *testing.T) {
+		plugin, host := newContainers()
+		host.KeyedComponent(databasePrimary, "primary")
+		require.Equal(t, "primary", plugin.GetByKey(databasePrimary))
+	})
+
+	t.Run("not-found-in-either", func(t *testing.T) {
+		plugin, _ := newContainers()
+		require.PanicsWithError(t, "injector: the requested dependency couldn't be found", func() {
+			plugin.Get("mocked-string")
+		})
+
+		require.PanicsWithError(t, "injector: couldn't find the dependency for string", func() {
+			plugin.Inject(&struct {
+				Name string `injector:"auto"`
+			}{})
+		})
+	})
+
+	t.Run("swapped", func(t *testing.T) {
+		plugin, _ := newContainers()
+		another := New()
+		another.NamedComponent("mocked-string", "another")
+		plugin.SetFallback(another)
+		require.Equal(t, "another", plugin.Get("mocked-string"))
+		require.Panics(t, func() {
+			plugin.Get("renderer")
+		})
+
+		plugin.SetFallback(nil)
+		require.Panics(t, func() {
+			plugin.Get("mocked-string")
+		})
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		plugin, host := newContainers()
+		require.PanicsWithError(t, "injector: setting the fallback results in a cycle", func() {
+			host.SetFallback(plugin)
+		})
+
+		require.PanicsWithError(t, "injector: setting the fallback results in a cycle", func() {
+			plugin.SetFallback(plugin)
+		})
+
+		require.PanicsWithError(t, "injector: setting the fallback results in a cycle", func() {
+			plugin.SetFallback(NewComposite(host, plugin))
+		})
+	})
+}
@@ -0,0 +1,28 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+)
+
+// NamedComponentWithFinalizer registers a component similar to NamedComponent and sets a finalizer
+// which is invoked with the component when it's garbage collected, to release resources of components
+// which are leaked. The component must be a pointer.
+//
+// As the Injector references the component, the finalizer can only run after the Injector is unreachable.
+// Finalizers run in a separate goroutine at an unspecified time after collection and may never run,
+// e.g. when the program exits, so they mustn't be relied on for regular teardown.
+func (c *Injector) NamedComponentWithFinalizer(name string, dep interface{}, finalizer func(component interface{})) {
+	defer annotatePanic("NamedComponentWithFinalizer", name)
+
+	v := reflect.ValueOf(dep)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		throw(fmt.Errorf("injector: a finalizer requires a non-nil pointer, %s is given", reflect.TypeOf(dep)))
+	}
+
+	c.NamedComponent(name, dep)
+	runtime.SetFinalizer(dep, func(component interface{}) {
+		finalizer(component)
+	})
+}
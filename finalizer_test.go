@@ -0,0 +1,48 @@
+package injector
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type resourceHolder struct {
+	name   string
+	buffer [64]byte
+}
+
+func Test_NamedComponentWithFinalizer(t *testing.T) {
+	t.Run("finalized", func(t *testing.T) {
+		finalized := make(chan string, 1)
+		func() {
+			c := New()
+			c.NamedComponentWithFinalizer("resource", &resourceHolder{name: "resource"}, func(component interface{}) {
+				finalized <- component.(*resourceHolder).name
+			})
+			require.Equal(t, "resource", c.Get("resource").(*resourceHolder).name)
+		}()
+
+		deadline := time.After(5 * time.Second)
+		for {
+			runtime.GC()
+			select {
+			case name := <-finalized:
+				require.Equal(t, "resource", name)
+				return
+			case <-deadline:
+				require.Fail(t, "the finalizer isn't invoked")
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	})
+
+	t.Run("not-pointer", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: a finalizer requires a non-nil pointer, injector.resourceHolder is given", func() {
+			c.NamedComponentWithFinalizer("resource", resourceHolder{}, func(component interface{}) {})
+		})
+	})
+}
@@ -0,0 +1,181 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Dependents returns names of components depending on the named component in registration order.
+// A component depends on another if it's injected into the component's fields or into the parameters
+// of its factory function or its Init method, by name or by type.
+// It's useful to analyse the impact of changing a component.
+func (c *Injector) Dependents(name string) []string {
+	dependents := []string{}
+	for _, dependentName := range c.names {
+		for _, dependsOn := range c.dependencies[dependentName].dependsOn {
+			if dependsOn == name {
+				dependents = append(dependents, dependentName)
+				break
+			}
+		}
+	}
+
+	return dependents
+}
+
+// Wiring returns what was injected into each field of the named component, keyed by field names.
+// A value is the name of the injected component, or its type if the injected value isn't registered,
+// e.g. an assembled collection or a composite, or "self" if the component is injected into itself.
+// It's useful to debug why a field received a particular value. An error is returned for unknown names.
+// Fields aren't recorded with WithFastMode.
+func (c *Injector) Wiring(name string) (map[string]string, error) {
+	dep, found := c.lookup(name)
+	if !found {
+		return nil, fmt.Errorf("injector: %s is not registered", name)
+	}
+
+	wiring := make(map[string]string, len(dep.wiring))
+	for field, injected := range dep.wiring {
+		wiring[field] = injected
+	}
+
+	return wiring, nil
+}
+
+// FactorySignature returns types of parameters and return values of the factory function which
+// created the named component, e.g. via NamedComponentFromFunc or NamedPrototypeFromFunc.
+// It's useful for tools documenting or validating constructors.
+// ok is false if the component isn't registered or isn't created by a factory function.
+func (c *Injector) FactorySignature(name string) (params []reflect.Type, returns []reflect.Type, ok bool) {
+	dep, found := c.lookup(name)
+	if !found || dep.factoryType == nil {
+		return nil, nil, false
+	}
+
+	params = make([]reflect.Type, 0, dep.factoryType.NumIn())
+	for i := 0; i < dep.factoryType.NumIn(); i++ {
+		params = append(params, dep.factoryType.In(i))
+	}
+
+	returns = make([]reflect.Type, 0, dep.factoryType.NumOut())
+	for i := 0; i < dep.factoryType.NumOut(); i++ {
+		returns = append(returns, dep.factoryType.Out(i))
+	}
+
+	return params, returns, true
+}
+
+// Subgraph returns a new Injector containing the named component and all components it depends on
+// transitively, including components injected by types and collections, in registration order.
+// Parameters of prototypes and collections which aren't assembled yet are followed as well.
+// Collections are copied, so adding elements to the subgraph doesn't affect the Injector.
+// Components are shared with the Injector rather than created again, while settings such as options,
+// combiners, adapters, defaults and the fallback are copied, so components of the fallback can still be resolved.
+// Subscribers of OnRegister aren't copied. It's useful to extract the minimal wiring of a component,
+// e.g. to test a single service. An error is returned for unknown names.
+func (c *Injector) Subgraph(name string) (*Injector, error) {
+	if _, found := c.lookup(name); !found && c.collectionOwner(name) == nil {
+		return nil, fmt.Errorf("injector: %s is not registered", name)
+	}
+
+	included := map[string]bool{}
+	pending := []string{name}
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		if included[current] {
+			continue
+		}
+
+		included[current] = true
+		if dep, found := c.lookup(current); found {
+			pending = append(pending, dep.dependsOn...)
+			if dep.prototype.IsValid() {
+				pending = append(pending, c.paramDependencies(dep.factoryType)...)
+			}
+
+			continue
+		}
+
+		if owner := c.collectionOwner(current); owner != nil {
+			col := owner.collections[current]
+			for _, element := range col.elements {
+				pending = append(pending, element.dependsOn...)
+			}
+
+			if !col.assembled {
+				for _, factoryFn := range col.factories {
+					pending = append(pending, c.paramDependencies(reflect.TypeOf(factoryFn))...)
+				}
+			}
+		}
+	}
+
+	sub := c.cloneSettings()
+	c.forEach(func(dep *dependency) {
+		if included[dep.name] {
+			sub.dependencies[dep.name] = dep
+			sub.names = append(sub.names, dep.name)
+		}
+	})
+
+	for collectionName := range included {
+		if owner := c.collectionOwner(collectionName); owner != nil {
+			col := *owner.collections[collectionName]
+			col.factories = append([]interface{}{}, col.factories...)
+			col.elements = append([]*dependency{}, col.elements...)
+			sub.collections[collectionName] = &col
+		}
+	}
+
+	for key, keyName := range c.keys {
+		if included[keyName] {
+			sub.keys[key] = keyName
+		}
+	}
+
+	return sub, nil
+}
+
+// paramDependencies returns names of components matching parameters of a factory function which isn't invoked yet,
+// e.g. of a prototype or a collection. All matches of a parameter are returned if it's ambiguous.
+func (c *Injector) paramDependencies(fnType reflect.Type) []string {
+	names := []string{}
+	for i := 0; i < fnType.NumIn(); i++ {
+		for _, candidate := range c.candidatesOf(fnType.In(i)) {
+			names = append(names, candidate.name)
+		}
+	}
+
+	return names
+}
+
+// cloneSettings creates a new empty Injector with the same settings, context and fallback.
+// Subscribers of OnRegister aren't copied as they react to registrations of the Injector they subscribe to.
+func (c *Injector) cloneSettings() *Injector {
+	clone := New()
+	clone.ctx = c.ctx
+	clone.fallback = c.fallback
+	clone.nameGenerator = c.nameGenerator
+	clone.matcher = c.matcher
+	clone.tagKeys = c.tagKeys
+	clone.logger = c.logger
+	clone.noAutoInjection = c.noAutoInjection
+	clone.optional = c.optional
+	clone.caseSensitive = c.caseSensitive
+	clone.maxDepth = c.maxDepth
+	clone.fastMode = c.fastMode
+	clone.initMethods = c.initMethods
+	clone.resolutionOrder = c.resolutionOrder
+	clone.afterInjects = append(clone.afterInjects, c.afterInjects...)
+	clone.adapters = append(clone.adapters, c.adapters...)
+	for t, combiner := range c.combiners {
+		clone.combiners[t] = combiner
+	}
+
+	for key, dep := range c.defaults {
+		clone.defaults[key] = dep
+	}
+
+	return clone
+}
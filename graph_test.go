@@ -0,0 +1,232 @@
+package injector
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockFactoryWithNamedInjection struct {
+	TypeA *TypeA `injector:"type-a"`
+}
+
+func (m mockFactoryWithNamedInjection) Create() (interface{}, error) {
+	return "created", nil
+}
+
+func Test_Dependents(t *testing.T) {
+	c := New()
+	c.NamedComponent("mocked-int", 10)
+	c.NamedComponent("type-a", &TypeA{})
+	c.NamedComponent("type-b", &TypeB{})
+	c.NamedComponent("type-d", &TypeD{})
+	c.NamedComponentFromFunc("from-func", func(a *TypeA) string {
+		return "created"
+	})
+	c.NamedComponentFromFactory("from-factory", &mockFactoryWithNamedInjection{})
+	c.NamedComponent("db.Host", "localhost")
+	c.NamedComponent("db.Port", 5432)
+	c.NamedComponent("config", &struct {
+		DB dbConfig `injector:"config,prefix=db"`
+	}{})
+
+	require.Equal(t, []string{"type-a", "type-d"}, c.Dependents("mocked-int"))
+	require.Equal(t, []string{"type-b", "from-func", "from-factory"}, c.Dependents("type-a"))
+	require.Equal(t, []string{"config"}, c.Dependents("db.Host"))
+	require.Empty(t, c.Dependents("type-b"))
+	require.Empty(t, c.Dependents("unknown"))
+}
+
+func Test_Wiring(t *testing.T) {
+	c := New()
+	c.NamedComponent("mocked-int", 10)
+	c.NamedComponent("type-a", &TypeA{})
+	c.NamedComponent("type-d", &TypeD{})
+	c.ProvideInto("renderers", func() Renderer { return mockRenderer("1") })
+	c.NamedComponent("node", &treeNode{})
+	c.NamedComponent("with-collection", &struct {
+		Renderers []Renderer `injector:"renderers"`
+	}{})
+
+	t.Run("named", func(t *testing.T) {
+		wiring, err := c.Wiring("type-a")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"Field": "mocked-int"}, wiring)
+	})
+
+	t.Run("auto", func(t *testing.T) {
+		wiring, err := c.Wiring("type-d")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"Field": "mocked-int"}, wiring)
+	})
+
+	t.Run("not-registered-value", func(t *testing.T) {
+		wiring, err := c.Wiring("with-collection")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"Renderers": "[]injector.Renderer"}, wiring)
+	})
+
+	t.Run("self", func(t *testing.T) {
+		wiring, err := c.Wiring("node")
+		require.NoError(t, err)
+		require.Equal(t, "self", wiring["Self"])
+	})
+
+	t.Run("no-fields", func(t *testing.T) {
+		wiring, err := c.Wiring("mocked-int")
+		require.NoError(t, err)
+		require.Empty(t, wiring)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := c.Wiring("unknown")
+		require.EqualError(t, err, "injector: unknown is not registered")
+	})
+}
+
+func Test_FactorySignature(t *testing.T) {
+	c := New()
+	c.NamedComponent("mocked-int", 10)
+	c.NamedComponentFromFunc("from-func", func(v int) (string, error) {
+		return "created", nil
+	})
+	c.NamedPrototypeFromFunc("prototype", func() *TypeA {
+		return &TypeA{}
+	})
+	c.NamedComponentFromFactory("from-factory", &mockFactory{mockResult: "created"})
+
+	t.Run("from-func", func(t *testing.T) {
+		params, returns, ok := c.FactorySignature("from-func")
+		require.True(t, ok)
+		require.Equal(t, []reflect.Type{reflect.TypeOf(0)}, params)
+		require.Equal(t, []reflect.Type{reflect.TypeOf(""), reflectTypeOfError}, returns)
+	})
+
+	t.Run("prototype", func(t *testing.T) {
+		params, returns, ok := c.FactorySignature("prototype")
+		require.True(t, ok)
+		require.Empty(t, params)
+		require.Equal(t, []reflect.Type{reflect.TypeOf(&TypeA{})}, returns)
+	})
+
+	t.Run("not-from-func", func(t *testing.T) {
+		for _, name := range []string{"mocked-int", "from-factory", "unknown"} {
+			_, _, ok := c.FactorySignature(name)
+			require.False(t, ok, name)
+		}
+	})
+}
+
+func Test_Subgraph(t *testing.T) {
+	c := New()
+	c.NamedComponent("mocked-int", 10)
+	c.NamedComponent("unrelated", "unrelated")
+	c.NamedComponent("type-a", &TypeA{})
+	c.NamedComponent("type-d", &TypeD{})
+	c.NamedComponentFromFunc("from-func", func(a *TypeA) string {
+		return "created"
+	})
+	c.ProvideInto("renderers", func(v int) Renderer {
+		return mockRenderer("1")
+	})
+	c.NamedComponent("with-collection", &struct {
+		Renderers []Renderer `injector:"renderers"`
+	}{})
+
+	t.Run("transitive", func(t *testing.T) {
+		sub, err := c.Subgraph("from-func")
+		require.NoError(t, err)
+		require.Equal(t, []string{"mocked-int", "type-a", "from-func"}, sub.names)
+		require.Same(t, c.Get("type-a"), sub.Get("type-a"))
+	})
+
+	t.Run("by-type", func(t *testing.T) {
+		sub, err := c.Subgraph("type-d")
+		require.NoError(t, err)
+		require.Equal(t, []string{"mocked-int", "type-d"}, sub.names)
+	})
+
+	t.Run("collection", func(t *testing.T) {
+		sub, err := c.Subgraph("with-collection")
+		require.NoError(t, err)
+		require.Equal(t, []string{"mocked-int", "with-collection"}, sub.names)
+		require.Equal(t, []interface{}{mockRenderer("1")}, sub.Get("renderers"))
+	})
+
+	t.Run("usable", func(t *testing.T) {
+		sub, err := c.Subgraph("type-a")
+		require.NoError(t, err)
+		sub.NamedComponent("type-b", &TypeB{})
+		require.False(t, c.isRegistered("type-b"))
+	})
+
+	t.Run("prototype", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("type-a", &TypeA{})
+		c.NamedComponent("unrelated", "unrelated")
+		c.NamedPrototypeFromFunc("prototype", func(a *TypeA) *TypeB {
+			return &TypeB{}
+		})
+
+		sub, err := c.Subgraph("prototype")
+		require.NoError(t, err)
+		require.Equal(t, []string{"mocked-int", "type-a", "prototype"}, sub.names)
+	})
+
+	t.Run("unassembled-collection", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("unrelated", "unrelated")
+		c.ProvideInto("renderers", func(v int) Renderer {
+			return mockRenderer("1")
+		})
+
+		sub, err := c.Subgraph("renderers")
+		require.NoError(t, err)
+		require.Equal(t, []string{"mocked-int"}, sub.names)
+		require.Equal(t, []interface{}{mockRenderer("1")}, sub.Get("renderers"))
+	})
+
+	t.Run("copied-collection", func(t *testing.T) {
+		c := New()
+		c.ProvideInto("renderers", func() Renderer {
+			return mockRenderer("1")
+		})
+
+		sub, err := c.Subgraph("renderers")
+		require.NoError(t, err)
+		sub.ProvideInto("renderers", func() Renderer {
+			return mockRenderer("2")
+		})
+		require.Equal(t, []interface{}{mockRenderer("1")}, c.Get("renderers"))
+		require.Equal(t, []interface{}{mockRenderer("1"), mockRenderer("2")}, sub.Get("renderers"))
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		host := New()
+		host.NamedComponent("mocked-int", 10)
+		plugin := New(WithContext(context.Background()))
+		plugin.SetFallback(host)
+		plugin.NamedComponent("type-a", &TypeA{})
+		subscribed := []string{}
+		plugin.OnRegister(func(name string) {
+			subscribed = append(subscribed, name)
+		})
+
+		sub, err := plugin.Subgraph("type-a")
+		require.NoError(t, err)
+		require.Equal(t, 10, sub.Get("mocked-int"))
+		sub.NamedComponent("another-type-a", &TypeA{})
+		require.Equal(t, 10, sub.Get("another-type-a").(*TypeA).Field)
+		require.Equal(t, plugin.ctx, sub.ctx)
+		require.Empty(t, subscribed)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := c.Subgraph("unknown")
+		require.EqualError(t, err, "injector: unknown is not registered")
+	})
+}
@@ -0,0 +1,126 @@
+package injector
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	groupPrefix      = "group:"
+	groupNamesPrefix = "names:"
+	minOption        = "min"
+)
+
+// isGroupTag returns true if the tag is in the form of `injector:"group:<pattern>"`.
+func isGroupTag(tag injectTag) bool {
+	return strings.HasPrefix(tag.name, groupPrefix)
+}
+
+// isGroupNamesTag returns true if the tag is in the form of `injector:"names:<pattern>"`.
+func isGroupNamesTag(tag injectTag) bool {
+	return strings.HasPrefix(tag.name, groupNamesPrefix)
+}
+
+// groupMembers returns components whose names match the glob pattern sorted by names.
+func (c *Injector) groupMembers(pattern string) ([]*dependency, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("injector: %s is an invalid pattern: %w", pattern, err)
+	}
+
+	deps := []*dependency{}
+	c.forEach(func(dep *dependency) {
+		if matched, _ := path.Match(pattern, dep.name); matched {
+			deps = append(deps, dep)
+		}
+	})
+	sort.Slice(deps, func(i, j int) bool {
+		return deps[i].name < deps[j].name
+	})
+
+	return deps, nil
+}
+
+// collectGroup collects components whose names match the glob pattern into a slice of type t sorted by names.
+// Each component must match the element type of t by the matcher of the Injector.
+// The syntax of the pattern is the same as path.Match, e.g. "handler.*".
+// With the min option, e.g. `injector:"group:plugin.*,min=1"`, fewer matching components is an error.
+func (c *Injector) collectGroup(tag injectTag, t reflect.Type) (*dependency, error) {
+	pattern := strings.TrimPrefix(tag.name, groupPrefix)
+	if t.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("injector: %s is a group, a slice is expected instead of %s", pattern, t)
+	}
+
+	deps, err := c.groupMembers(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag.has(minOption) {
+		minCount, err := strconv.Atoi(tag.options[minOption])
+		if err != nil || minCount < 0 {
+			return nil, fmt.Errorf("injector: %q is an invalid minimum of group %s, a non-negative integer is expected", tag.options[minOption], pattern)
+		}
+
+		if len(deps) < minCount {
+			return nil, fmt.Errorf("injector: group %s has %d matching components but requires at least %d", pattern, len(deps), minCount)
+		}
+	}
+
+	names := make([]string, 0, len(deps))
+	slice := reflect.MakeSlice(t, 0, len(deps))
+	for _, dep := range deps {
+		dep, err := c.resolve(dep.name, dep)
+		if err != nil {
+			return nil, err
+		}
+
+		if !c.matcher(dep.reflectType, t.Elem()) {
+			return nil, fmt.Errorf("injector: %s is not assignable from %s in %s", t.Elem(), dep.reflectType, dep.name)
+		}
+
+		v, err := convertValue(dep.reflectValue, t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, dep.name)
+		slice = reflect.Append(slice, v)
+	}
+
+	return &dependency{
+		value:        slice.Interface(),
+		reflectValue: slice,
+		reflectType:  t,
+		dependsOn:    names,
+	}, nil
+}
+
+// collectGroupNames collects names of components whose names match the glob pattern into a slice of strings.
+// Names are in the same order as components collected by collectGroup with the same pattern,
+// so a field tagged with `injector:"names:handler.*"` lists names of components in a field tagged with
+// `injector:"group:handler.*"`, e.g. for logging dynamically assembled groups.
+func (c *Injector) collectGroupNames(pattern string, t reflect.Type) (*dependency, error) {
+	if t.Kind() != reflect.Slice || t.Elem().Kind() != reflect.String {
+		return nil, fmt.Errorf("injector: %s is names of a group, a slice of strings is expected instead of %s", pattern, t)
+	}
+
+	deps, err := c.groupMembers(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	names := reflect.MakeSlice(t, 0, len(deps))
+	for _, dep := range deps {
+		names = reflect.Append(names, reflect.ValueOf(dep.name).Convert(t.Elem()))
+	}
+
+	return &dependency{
+		value:        names.Interface(),
+		reflectValue: names,
+		reflectType:  t,
+	}, nil
+}
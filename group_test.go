@@ -0,0 +1,131 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Inject_group(t *testing.T) {
+	c := New()
+	c.NamedComponent("handler.users", mockRenderer("users"))
+	c.NamedComponent("handler.orders", mockRenderer("orders"))
+	c.NamedComponent("handler.v2.users", mockRenderer("v2-users"))
+	c.NamedComponent("renderer", mockRenderer("renderer"))
+
+	t.Run("matched", func(t *testing.T) {
+		target := &struct {
+			Handlers []Renderer `injector:"group:handler.*"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, []Renderer{mockRenderer("orders"), mockRenderer("users"), mockRenderer("v2-users")}, target.Handlers)
+	})
+
+	t.Run("no-match", func(t *testing.T) {
+		target := &struct {
+			Handlers []Renderer `injector:"group:missing.*"`
+		}{}
+		c.Inject(target)
+		require.Empty(t, target.Handlers)
+		require.NotNil(t, target.Handlers)
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		c := New(WithOptionalByDefault())
+		c.NamedComponent("handler.users", mockRenderer("users"))
+		target := &struct {
+			Handlers []Renderer `injector:"group:handler.*"`
+		}{}
+		c.Inject(target)
+		require.Len(t, target.Handlers, 1)
+	})
+
+	t.Run("not-assignable", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("handler.users", mockRenderer("users"))
+		c.NamedComponent("handler.count", 10)
+		require.PanicsWithError(t, "injector: injector.Renderer is not assignable from int in handler.count", func() {
+			c.Inject(&struct {
+				Handlers []Renderer `injector:"group:handler.*"`
+			}{})
+		})
+	})
+
+	t.Run("not-slice", func(t *testing.T) {
+		require.PanicsWithError(t, "injector: handler.* is a group, a slice is expected instead of injector.Renderer", func() {
+			c.Inject(&struct {
+				Handler Renderer `injector:"group:handler.*"`
+			}{})
+		})
+	})
+
+	t.Run("min", func(t *testing.T) {
+		target := &struct {
+			Handlers []Renderer `injector:"group:handler.*,min=3"`
+		}{}
+		c.Inject(target)
+		require.Len(t, target.Handlers, 3)
+
+		require.PanicsWithError(t, "injector: group plugin.* has 0 matching components but requires at least 1", func() {
+			c.Inject(&struct {
+				Plugins []Renderer `injector:"group:plugin.*,min=1"`
+			}{})
+		})
+
+		require.PanicsWithError(t, `injector: "-1" is an invalid minimum of group handler.*, a non-negative integer is expected`, func() {
+			c.Inject(&struct {
+				Handlers []Renderer `injector:"group:handler.*,min=-1"`
+			}{})
+		})
+	})
+
+	t.Run("invalid-pattern", func(t *testing.T) {
+		require.PanicsWithError(t, "injector: handler.[ is an invalid pattern: syntax error in pattern", func() {
+			c.Inject(&struct {
+				Handlers []Renderer `injector:"group:handler.["`
+			}{})
+		})
+	})
+}
+
+func Test_Inject_group_names(t *testing.T) {
+	c := New()
+	c.NamedComponent("handler.users", mockRenderer("users"))
+	c.NamedComponent("handler.orders", mockRenderer("orders"))
+	c.NamedComponent("renderer", mockRenderer("renderer"))
+
+	t.Run("same-order", func(t *testing.T) {
+		target := &struct {
+			Handlers     []Renderer `injector:"group:handler.*"`
+			HandlerNames []string   `injector:"names:handler.*"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, []Renderer{mockRenderer("orders"), mockRenderer("users")}, target.Handlers)
+		require.Equal(t, []string{"handler.orders", "handler.users"}, target.HandlerNames)
+	})
+
+	t.Run("no-match", func(t *testing.T) {
+		target := &struct {
+			HandlerNames []string `injector:"names:missing.*"`
+		}{}
+		c.Inject(target)
+		require.Empty(t, target.HandlerNames)
+		require.NotNil(t, target.HandlerNames)
+	})
+
+	t.Run("not-strings", func(t *testing.T) {
+		require.PanicsWithError(t, "injector: handler.* is names of a group, a slice of strings is expected instead of []int", func() {
+			c.Inject(&struct {
+				HandlerNames []int `injector:"names:handler.*"`
+			}{})
+		})
+	})
+
+	t.Run("invalid-pattern", func(t *testing.T) {
+		require.PanicsWithError(t, "injector: handler.[ is an invalid pattern: syntax error in pattern", func() {
+			c.Inject(&struct {
+				HandlerNames []string `injector:"names:handler.["`
+			}{})
+		})
+	})
+}
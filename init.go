@@ -0,0 +1,71 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const initMethodName = "Init"
+
+// Initializer is implemented by components that need the Injector to initialize themselves,
+// e.g. to load dependencies which aren't stored as fields.
+// InitWith is invoked after dependencies are injected into the component and before it's registered.
+type Initializer interface {
+	InitWith(c *Injector) error
+}
+
+// WithInitMethods enables invoking Init methods with parameters of components, the parameters are resolved
+// by types, e.g. Init(logger Logger) error. Init methods without parameters aren't invoked.
+// Init must return nothing or an error. As third-party types may have unrelated Init methods,
+// e.g. flag.FlagSet, it's disabled by default and implementing Initializer is preferred.
+func WithInitMethods() Option {
+	return func(c *Injector) {
+		c.initMethods = true
+	}
+}
+
+// initialize invokes InitWith if the component implements Initializer. Otherwise, if WithInitMethods
+// is used and the component has an Init method with parameters, the parameters are resolved by types
+// and Init is invoked.
+func (c *Injector) initialize(name string, dep *dependency) error {
+	// nil pointers and nil interfaces have no methods to invoke
+	kind := dep.reflectValue.Kind()
+	if (kind == reflect.Ptr || kind == reflect.Interface) && dep.reflectValue.IsNil() {
+		return nil
+	}
+
+	if initializer, ok := dep.value.(Initializer); ok {
+		if err := initializer.InitWith(c); err != nil {
+			return fmt.Errorf("injector: failed to initialize %s: %w", name, err)
+		}
+
+		return nil
+	}
+
+	if !c.initMethods {
+		return nil
+	}
+
+	method := dep.reflectValue.MethodByName(initMethodName)
+	if !method.IsValid() || method.Type().NumIn() == 0 {
+		return nil
+	}
+
+	methodType := method.Type()
+	if methodType.NumOut() > 1 || (methodType.NumOut() == 1 && !implementsError(methodType.Out(0))) {
+		return fmt.Errorf("injector: Init of %s must return nothing or an error", name)
+	}
+
+	params, dependsOn, err := c.generateInParams(methodType, nil)
+	if err != nil {
+		return fmt.Errorf("injector: failed to initialize %s: %w", name, err)
+	}
+
+	out := method.Call(params)
+	if len(out) == 1 && !out[0].IsNil() {
+		return fmt.Errorf("injector: failed to initialize %s: %w", name, out[0].Interface().(error))
+	}
+
+	dep.dependsOn = append(dep.dependsOn, dependsOn...)
+	return nil
+}
@@ -0,0 +1,132 @@
+package injector
+
+import (
+	"errors"
+	"flag"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type initWithComponent struct {
+	Field int
+	err   error
+}
+
+func (i *initWithComponent) InitWith(c *Injector) error {
+	i.Field = c.Get("mocked-int").(int)
+	return i.err
+}
+
+type initComponent struct {
+	Field int
+}
+
+func (i *initComponent) Init(v int, r Renderer) error {
+	if v < 0 {
+		return errors.New("negative value")
+	}
+
+	i.Field = v
+	return nil
+}
+
+type initNoParamsComponent struct {
+	initialized bool
+}
+
+func (i *initNoParamsComponent) Init() {
+	i.initialized = true
+}
+
+type initInvalidComponent struct{}
+
+func (i *initInvalidComponent) Init(v int) int {
+	return v
+}
+
+func Test_initialize(t *testing.T) {
+	t.Run("init-with", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		component := &initWithComponent{}
+		c.Component(component)
+		require.Equal(t, 10, component.Field)
+	})
+
+	t.Run("init-with-error", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		require.PanicsWithError(t, "injector: failed to initialize component: random error", func() {
+			c.NamedComponent("component", &initWithComponent{err: errors.New("random error")})
+		})
+	})
+
+	t.Run("init-with-params", func(t *testing.T) {
+		c := New(WithInitMethods())
+		c.NamedComponent("mocked-int", 10)
+		c.Component(&rendererImpl{})
+		component := &initComponent{}
+		c.Component(component)
+		require.Equal(t, 10, component.Field)
+	})
+
+	t.Run("init-missing-param", func(t *testing.T) {
+		c := New(WithInitMethods())
+		c.NamedComponent("mocked-int", 10)
+		require.PanicsWithError(t, "injector: failed to initialize component: injector: couldn't find the dependency for injector.Renderer", func() {
+			c.NamedComponent("component", &initComponent{})
+		})
+		require.NotContains(t, c.dependencies, "component")
+	})
+
+	t.Run("init-error", func(t *testing.T) {
+		c := New(WithInitMethods())
+		c.NamedComponent("mocked-int", -1)
+		c.Component(&rendererImpl{})
+		require.PanicsWithError(t, "injector: failed to initialize component: negative value", func() {
+			c.NamedComponent("component", &initComponent{})
+		})
+	})
+
+	t.Run("init-without-params", func(t *testing.T) {
+		c := New(WithInitMethods())
+		component := &initNoParamsComponent{}
+		c.Component(component)
+		require.False(t, component.initialized)
+	})
+
+	t.Run("init-methods-disabled", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("mocked-string", "prod")
+		c.NamedComponent("error-handling", flag.PanicOnError)
+		flags := flag.NewFlagSet("flags", flag.ContinueOnError)
+		c.NamedComponent("flags", flags)
+		require.Equal(t, "flags", flags.Name())
+
+		component := &initComponent{}
+		c.NamedComponent("component", component)
+		require.Zero(t, component.Field)
+	})
+
+	t.Run("nil-interface", func(t *testing.T) {
+		c := New(WithInitMethods())
+		c.NamedComponent("mocked-int", 10)
+		var component interface{ Init(v int) int }
+		err := Run(func() {
+			c.RegisterValue("component", reflect.ValueOf(&component).Elem())
+		})
+		require.NoError(t, err)
+		require.Nil(t, c.Get("component"))
+	})
+
+	t.Run("invalid-init", func(t *testing.T) {
+		c := New(WithInitMethods())
+		c.NamedComponent("mocked-int", 10)
+		require.PanicsWithError(t, "injector: Init of component must return nothing or an error", func() {
+			c.NamedComponent("component", &initInvalidComponent{})
+		})
+	})
+}
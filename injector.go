@@ -13,26 +13,122 @@
 // format used by the various standard libraries, like json, xml etc. It
 // involves tags in one of the form below:
 //
-//     `injector:"logger"`
+//	`injector:"logger"`
 //
 // The above form is asking for a named dependency called "logger".
+// Options can follow the name and are separated by commas, e.g. `injector:"auto,composite"`.
+// A component can receive a reference to itself with `injector:"self"`, e.g. for recursion.
+// With the create option, e.g. `injector:"auto,create"`, a field of type *T where T is a struct
+// receives a new T with dependencies injected if no component of type *T is registered.
+// Components whose names match a glob pattern can be injected into a slice, sorted by names,
+// with `injector:"group:handler.*"`. Their names can be injected into a companion slice of strings
+// in the same order with `injector:"names:handler.*"`, linked by the same pattern.
+// A minimum number of components can be required, e.g. `injector:"group:plugin.*,min=1"`.
+// Names can contain placeholders which are substituted by registered string components, e.g.
+// `injector:"db.{env}.url"` injects "db.prod.url" if the component named "env" is "prod".
+// Alternatives separated by "|" are tried in order, e.g. `injector:"logger|auto"` injects
+// the component named "logger" if it's registered and falls back to injecting by type otherwise.
+// With WithOptionalByDefault, a field whose named dependency isn't registered is left untouched
+// unless it has the required option, e.g. `injector:"logger,required"`.
+// With the ifempty option, e.g. `injector:"auto,ifempty"`, a field is only injected if it's
+// the zero value, so fields populated manually are left untouched.
+// A config struct can be injected with `injector:"config,prefix=db"`, its exported fields
+// are loaded from components named "db.<field name>", missing ones are skipped with WithOptionalByDefault.
+//
+// Values which aren't pointers, e.g. int or time.Time, are stored as is and nothing is injected
+// into them. A struct value with injector tags can't be registered as its fields aren't settable,
+// unless it's returned by a factory function, then dependencies are injected into a copy of it.
+//
+// Function values can be registered and injected by types as well. As Go's assignability
+// rules apply, a function only satisfies a field whose signature is identical, e.g. a
+// func(string) error handler is never injected into a func(string) field.
+//
+// An interface field is only non-nil if a component is injected into it. Registering a typed nil
+// such as (*LoggerImpl)(nil) is allowed and results in a non-nil interface wrapping a nil pointer,
+// while registering an untyped nil isn't allowed.
+//
+// Interfaces are matched by method sets, so a component can be injected into any interface it implements,
+// including instances of generic interfaces, e.g. Store[User], while Store[User] and Store[Order] are
+// different types. Only instantiations of generic types can be registered, and type arguments aren't
+// inferred, e.g. a Repository[any] component doesn't match Store[User]. Constraint interfaces such as
+// ones with type unions or comparable can't be types of fields or parameters in Go, hence they can't be
+// injected. If several components match a type, the only component registered exactly as that type,
+// e.g. an interface registered via RegisterValue, takes precedence, otherwise it's a conflict.
+//
+// A field of type *I where I is an interface can only be filled by a registered *I component,
+// the interface type I should be used for the field instead.
+//
+// A field of type **T can be filled by a *T dependency. As registered dependencies
+// aren't addressable, the field receives a new pointer to the registered *T.
 package injector
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"reflect"
+	"sort"
+	"strings"
+	"time"
 )
 
 const (
 	autoInjectionTag = "auto"
+	selfInjectionTag = "self"
 	unnamedPrefix    = "unnamed"
+	defaultTagKey    = "injector"
 )
 
 type dependency struct {
+	name         string
 	value        interface{}
 	reflectValue reflect.Value
 	reflectType  reflect.Type
+	placeholder  bool
+	// factoryType is the type of the factory function which created the dependency if any.
+	factoryType reflect.Type
+	// prototype is the factory function creating a new instance every time the dependency is resolved.
+	prototype reflect.Value
+	// creating is true while an instance of the prototype is being created.
+	creating bool
+	// dependsOn contains names of components the dependency depends on.
+	dependsOn []string
+	// wiring maps names of injected fields to what was injected into them.
+	wiring map[string]string
+}
+
+// references returns names of registered components which the dependency refers to.
+// A dependency which isn't registered, e.g. an assembled collection, refers to components it depends on.
+func (d *dependency) references() []string {
+	if d.name != "" {
+		return []string{d.name}
+	}
+
+	return d.dependsOn
+}
+
+// describe returns the name of the dependency, or its type if it isn't registered.
+func (d *dependency) describe() string {
+	if d.name != "" {
+		return d.name
+	}
+
+	return d.reflectType.String()
+}
+
+// recordWiring records what was injected into a field of the dependency for Wiring.
+// It's skipped in fast mode.
+func (c *Injector) recordWiring(d *dependency, field, injected string) {
+	if c.fastMode {
+		return
+	}
+
+	if d.wiring == nil {
+		d.wiring = map[string]string{}
+	}
+
+	d.wiring[field] = injected
 }
 
 // Factory defines a factory that creates a new component.
@@ -40,71 +136,276 @@ type Factory interface {
 	Create() (interface{}, error)
 }
 
-// New creates a new instance of Injector.
-func New() *Injector {
-	return &Injector{
-		dependencies: map[string]*dependency{},
+// New creates a new instance of Injector. Options can be given to customize the Injector.
+func New(opts ...Option) *Injector {
+	c := &Injector{
+		nameGenerator: defaultNameGenerator,
+		matcher:       reflect.Type.AssignableTo,
+		tagKeys:       []string{defaultTagKey},
+		dependencies:  map[string]*dependency{},
+		collections:   map[string]*collection{},
+		keys:          map[interface{}]string{},
+		defaults:      map[string]*dependency{},
+		combiners:     map[reflect.Type]reflect.Value{},
+		taggedTypes:   map[reflect.Type]bool{},
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.buildInfo != nil {
+		c.NamedComponent(buildInfoName, *c.buildInfo)
+	}
+
+	if c.ctx != nil {
+		c.RegisterValue(contextName, reflect.ValueOf(&c.ctx).Elem())
+	}
+
+	// components registered by options are left out of the recording
+	// as they are registered again when it's replayed with the same options.
+	c.operations = nil
+	return c
 }
 
 // Injector contains all dependencies. An injector can be created by New method.
 type Injector struct {
-	dependencies   map[string]*dependency
-	unnamedCounter int
+	dependencies    map[string]*dependency
+	names           []string
+	collections     map[string]*collection
+	keys            map[interface{}]string
+	defaults        map[string]*dependency
+	caseSensitive   bool
+	maxDepth        int
+	fastMode        bool
+	initMethods     bool
+	resolutionOrder ResolutionOrder
+	resolving       []string
+	combiners       map[reflect.Type]reflect.Value
+	adapters        []reflect.Value
+	taggedTypes     map[reflect.Type]bool
+	tagKeys         []string
+	unnamedCounter  int
+	nameGenerator   func(t reflect.Type, index int) string
+	matcher         func(depType, fieldType reflect.Type) bool
+	logger          *slog.Logger
+	frozen          bool
+	readOnly        bool
+	sources         []*Injector
+	fallback        *Injector
+	buildInfo       *BuildInfo
+	ctx             context.Context
+	timings         map[string]time.Duration
+	noAutoInjection bool
+	optional        bool
+	afterInjects    []func(name string, component interface{}) error
+	onRegisters     []func(name string)
+	operations      []Operation
+	operationDepth  int
 }
 
 // NamedComponent registers new dependency with a name to the Injector. As name has to be unique,
 // it returns an error if name is not unique. An error is also returned if the function is unable to inject dependencies.
 // A factory function can be used:
 //
-// func newLogger() (Logger, error) {
-//   // initialize a new logger
-// }
+//	func newLogger() (Logger, error) {
+//	  // initialize a new logger
+//	}
 //
 // we then use c.NamedComponent("logger", newLogger) to register the logger dependency with that function.
 // dependencies are also injected to the newly created struct from the factory function.
 func (c *Injector) NamedComponent(name string, dep interface{}) {
+	defer annotatePanic("NamedComponent", name)
+	defer c.recordOperation("NamedComponent", name, dep)()
+
 	c.validateNamne(name)
 
-	toAddDep := &dependency{
+	c.register(name, &dependency{
 		value:        dep,
 		reflectType:  reflect.TypeOf(dep),
 		reflectValue: reflect.ValueOf(dep),
+	})
+}
+
+// NamedComponentIfAvailable registers the component provided by probe only if it's available,
+// e.g. a hardware accelerator which may be missing. The probe is invoked once at registration and returns
+// the component and whether it's available. If it isn't, the name is left unregistered,
+// so dependents have to treat it as optional, e.g. via WithOptionalByDefault or the ifempty option.
+func (c *Injector) NamedComponentIfAvailable(name string, probe func() (interface{}, bool)) {
+	defer annotatePanic("NamedComponentIfAvailable", name)
+
+	c.validateNamne(name)
+
+	dep, available := probe()
+	if !available {
+		c.debug("injector: component isn't available", "name", name)
+		return
+	}
+
+	c.NamedComponent(name, dep)
+}
+
+// NamedComponentUnique is similar to NamedComponent, instead it returns an error if a registered component
+// has the same type as dep, as injecting that type by `injector:"auto"` would result in a conflict.
+// It keeps types unambiguous for injecting by types while components can still be injected by name.
+// Errors of the registration are returned instead of panicking.
+func (c *Injector) NamedComponentUnique(name string, dep interface{}) error {
+	return Run(func() {
+		t := reflect.TypeOf(dep)
+		c.forEach(func(registered *dependency) {
+			if t != nil && registered.reflectType == t {
+				throw(fmt.Errorf("injector: %s has the same type %s as %s", name, t, registered.name))
+			}
+		})
+
+		c.NamedComponent(name, dep)
+	})
+}
+
+// RegisterValue registers a dependency with a name from a reflect.Value. It's aimed at code generators
+// and tools that already hold reflect.Values as it avoids boxing the value to interface{} and reflecting it again.
+// The value must be valid and must not be obtained via unexported struct fields.
+func (c *Injector) RegisterValue(name string, v reflect.Value) {
+	defer annotatePanic("RegisterValue", name)
+	defer c.recordOperation("RegisterValue", name, v)()
+
+	c.validateNamne(name)
+
+	if !v.IsValid() {
+		throw(errors.New("injector: an invalid reflect.Value can't be registered"))
 	}
 
-	if err := c.populate(toAddDep); err != nil {
-		panic(err)
+	if !v.CanInterface() {
+		throw(fmt.Errorf("injector: %s is obtained via unexported fields and can't be registered", v.Type()))
 	}
 
-	c.dependencies[name] = toAddDep
+	c.register(name, &dependency{
+		value:        v.Interface(),
+		reflectType:  v.Type(),
+		reflectValue: v,
+	})
 }
 
 // NamedComponentFromFunc creates a new named component from a factory function
 // and registers the created component to the injector.
 func (c *Injector) NamedComponentFromFunc(name string, factoryFn interface{}) {
+	defer annotatePanic("NamedComponentFromFunc", name)
+
+	c.NamedComponentFromFuncWith(name, factoryFn)
+}
+
+// NamedComponentsFromFunc registers a component for every name by invoking factoryFn once per name,
+// e.g. for several worker pools of the same type. Parameters are resolved for every invocation and dependencies
+// are injected into every created component, so components are distinct instances.
+// It differs from registering one component under several names, which share the same instance.
+// Names are validated before factoryFn is invoked, so no component is registered if any name is invalid.
+func (c *Injector) NamedComponentsFromFunc(names []string, factoryFn interface{}) {
+	defer annotatePanic("NamedComponentsFromFunc", "")
+
+	for i, name := range names {
+		c.validateNamne(name)
+		for _, previous := range names[:i] {
+			if previous == name {
+				throw(fmt.Errorf("injector: %s is duplicated", name))
+			}
+		}
+	}
+
+	for _, name := range names {
+		c.NamedComponentFromFunc(name, factoryFn)
+	}
+}
+
+// NamedComponentFromFuncWith is similar to NamedComponentFromFunc, instead the given overrides are used
+// to satisfy parameters of the factory function before falling back to the injector.
+// An override is used for a parameter if it's assignable to the parameter type,
+// there must not be more than one override assignable to the same parameter.
+func (c *Injector) NamedComponentFromFuncWith(name string, factoryFn interface{}, overrides ...interface{}) {
+	defer annotatePanic("NamedComponentFromFuncWith", name)
+	defer c.recordOperation("NamedComponentFromFuncWith", append([]interface{}{name, factoryFn}, overrides...)...)()
+
 	c.validateNamne(name)
 
 	fnType := reflect.TypeOf(factoryFn)
-	if fnType.Kind() != reflect.Func {
-		panic(errors.New("injector: a factory function is expected"))
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		throw(errors.New("injector: a factory function is expected"))
 	}
 
-	createdDep, err := c.executeFunc(factoryFn, fnType)
+	start := c.startTiming()
+	createdDep, err := c.executeFunc(factoryFn, fnType, overrides)
 	if err != nil {
-		panic(err)
+		throw(err)
 	}
 
-	if err := c.populate(createdDep); err != nil {
-		panic(err)
+	c.register(name, createdDep)
+	c.recordTiming(name, start)
+}
+
+// NamedComponentWith creates a new named component by invoking fn with the given arguments by position,
+// instead of resolving its parameters from the injector, and registers the created component.
+// It's handy for constructors whose arguments are known values. Each argument must be assignable to
+// its parameter, numbers are converted only if the conversion is exact, e.g. an int argument for a time.Duration
+// parameter, while 1.9 for an int parameter or 300 for an int8 parameter are rejected.
+// Dependencies are still injected into the created component.
+func (c *Injector) NamedComponentWith(name string, fn interface{}, args ...interface{}) {
+	defer annotatePanic("NamedComponentWith", name)
+	defer c.recordOperation("NamedComponentWith", append([]interface{}{name, fn}, args...)...)()
+
+	c.validateNamne(name)
+
+	fnType := reflect.TypeOf(fn)
+	if fnType == nil || fnType.Kind() != reflect.Func || fnType.IsVariadic() {
+		throw(errors.New("injector: a non-variadic function is expected"))
+	}
+
+	if err := validateFactoryOutputs(fnType); err != nil {
+		throw(err)
+	}
+
+	if len(args) != fnType.NumIn() {
+		throw(fmt.Errorf("injector: %d arguments are given while %d are expected", len(args), fnType.NumIn()))
 	}
 
-	c.dependencies[name] = createdDep
+	inParams := make([]reflect.Value, 0, len(args))
+	for i, arg := range args {
+		param, err := argumentOf(arg, fnType.In(i))
+		if err != nil {
+			throw(fmt.Errorf("injector: argument %d: %w", i, err))
+		}
+
+		inParams = append(inParams, param)
+	}
+
+	start := c.startTiming()
+	createdDep, err := callFactory(fn, fnType, inParams, nil)
+	if err != nil {
+		throw(err)
+	}
+
+	c.register(name, createdDep)
+	c.recordTiming(name, start)
 }
 
 // ComponentFromFunc creates a new component from a factory function.
 // It's similar to NamedComponentFromFunc, instead a name will be generated for the component.
-func (c *Injector) ComponentFromFunc(factoryFn interface{}) {
-	c.NamedComponentFromFunc(c.nextGeneratedName(), factoryFn)
+// The generated name is returned so the component can be retrieved later.
+func (c *Injector) ComponentFromFunc(factoryFn interface{}) string {
+	defer annotatePanic("ComponentFromFunc", "")
+
+	fnType := reflect.TypeOf(factoryFn)
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		throw(errors.New("injector: a factory function is expected"))
+	}
+
+	if err := validateFactoryOutputs(fnType); err != nil {
+		throw(err)
+	}
+
+	name := c.nextGeneratedName(fnType.Out(0))
+	defer annotatePanic("ComponentFromFunc", name)
+
+	c.NamedComponentFromFunc(name, factoryFn)
+	return name
 }
 
 // ComponentFromFactory creates a new component by invoking the Create function in a given factory.
@@ -112,45 +413,184 @@ func (c *Injector) ComponentFromFunc(factoryFn interface{}) {
 // After creating the component, it will inject dependencies to the component as well.
 // It returns error if there is any.
 //
-// With ComponentFromFactory, the name will be generated for the generated component.
-func (c *Injector) ComponentFromFactory(f Factory) {
-	c.NamedComponentFromFactory(c.nextGeneratedName(), f)
+// With ComponentFromFactory, the name will be generated for the generated component
+// and it's returned so the component can be retrieved later.
+func (c *Injector) ComponentFromFactory(f Factory) string {
+	defer annotatePanic("ComponentFromFactory", "")
+
+	c.validateFrozen()
+	start := c.startTiming()
+	dep := c.createFromFactory(f)
+	if dep.reflectType == nil {
+		throw(errUntypedNil)
+	}
+
+	name := c.nextGeneratedName(dep.reflectType)
+	defer annotatePanic("ComponentFromFactory", name)
+	defer c.recordOperation("NamedComponentFromFactory", name, f)()
+
+	c.validateNamne(name)
+	c.register(name, dep)
+	c.recordTiming(name, start)
+	return name
 }
 
 // NamedComponentFromFactory creates a new component by invoking the Create function in a given factory.
 // Before creating the component, it will inject dependencies into the factory.
 // After creating the component, it will inject dependencies to the component as well.
 func (c *Injector) NamedComponentFromFactory(name string, f Factory) {
-	c.Inject(f)
+	defer annotatePanic("NamedComponentFromFactory", name)
+	defer c.recordOperation("NamedComponentFromFactory", name, f)()
+
+	c.validateNamne(name)
+	start := c.startTiming()
+	c.register(name, c.createFromFactory(f))
+	c.recordTiming(name, start)
+}
+
+// createFromFactory injects dependencies into the factory and creates a new component.
+// The created component depends on what the factory depends on.
+func (c *Injector) createFromFactory(f Factory) *dependency {
+	factoryDep := &dependency{
+		value:        f,
+		reflectType:  reflect.TypeOf(f),
+		reflectValue: reflect.ValueOf(f),
+	}
+
+	if err := c.populate(factoryDep); err != nil {
+		throw(err)
+	}
 
 	component, err := f.Create()
 	if err != nil {
-		panic(err)
+		throw(err)
 	}
 
-	c.NamedComponent(name, component)
+	return &dependency{
+		value:        component,
+		reflectType:  reflect.TypeOf(component),
+		reflectValue: reflect.ValueOf(component),
+		dependsOn:    factoryDep.dependsOn,
+	}
 }
 
 // Get loads a dependency from the Injector using name.
 func (c *Injector) Get(name string) interface{} {
-	dep, found := c.dependencies[name]
+	defer annotatePanic("Get", name)
+
+	if owner := c.collectionOwner(name); owner != nil {
+		dep, err := owner.assembleCollection(name, reflectTypeOfInterfaces)
+		if err != nil {
+			throw(err)
+		}
+
+		return dep.value
+	}
+
+	dep, found := c.lookup(name)
 	if !found {
-		panic(errors.New("injector: the requested dependency couldn't be found"))
+		throw(errors.New("injector: the requested dependency couldn't be found"))
+	}
+
+	dep, err := c.resolve(name, dep)
+	if err != nil {
+		throw(err)
 	}
 
 	return dep.value
 }
 
+// GetByPrefix loads all components whose names start with prefix, keyed by their names.
+// It's handy to collect a family of components registered with a naming convention,
+// e.g. "handler.users" and "handler.orders". An empty map is returned if nothing matches.
+func (c *Injector) GetByPrefix(prefix string) map[string]interface{} {
+	defer annotatePanic("GetByPrefix", "")
+
+	components := map[string]interface{}{}
+	c.forEach(func(dep *dependency) {
+		if strings.HasPrefix(dep.name, prefix) && !dep.placeholder {
+			components[dep.name] = c.mustResolve(dep).value
+		}
+	})
+
+	return components
+}
+
+// ResolveAuto returns the name of the component that would be injected into a field of type t
+// tagged with `injector:"auto"`. It returns an error if no component or more than one component matches.
+// It's useful to preview how dependencies are injected by types.
+func (c *Injector) ResolveAuto(t reflect.Type) (string, error) {
+	name, _, err := c.findByType(t)
+	return name, err
+}
+
+// IsAmbiguous returns true if more than one component matches t, so injecting t by type results in a conflict.
+// It's useful to detect ambiguous types proactively, e.g. at startup. As in injecting by types,
+// a component registered exactly as t takes precedence over other matches, and with WithResolutionOrder
+// no type is ambiguous. If no component matches, t is ambiguous if more than one component can be adapted
+// or, without an eligible adapter, if it's ambiguous in the fallback.
+func (c *Injector) IsAmbiguous(t reflect.Type) bool {
+	if candidates := c.candidatesOf(t); len(candidates) > 0 {
+		return len(candidates) > 1
+	}
+
+	if _, adapted, conflicted := c.findAdapter(t); adapted != nil {
+		return conflicted
+	}
+
+	return c.fallback != nil && c.fallback.IsAmbiguous(t)
+}
+
+// AssertImplements returns an error if the named component doesn't implement all interfaces given as pointers,
+// e.g. c.AssertImplements("store", (*io.Closer)(nil)). The dynamic type of the component is checked,
+// so a component registered as an interface satisfies interfaces implemented by its underlying value.
+// It's useful to validate contracts of loosely coupled components at startup.
+func (c *Injector) AssertImplements(name string, ifacePtrs ...interface{}) error {
+	return Run(func() {
+		component := c.Get(name)
+		t := reflect.TypeOf(component)
+		missing := []string{}
+		for _, ifacePtr := range ifacePtrs {
+			ifaceType := reflect.TypeOf(ifacePtr)
+			if ifaceType == nil || !isPtrToInterface(ifaceType) {
+				throw(fmt.Errorf("injector: %v is not a pointer to an interface", ifaceType))
+			}
+
+			if t == nil || !t.Implements(ifaceType.Elem()) {
+				missing = append(missing, ifaceType.Elem().String())
+			}
+		}
+
+		if len(missing) > 0 {
+			throw(fmt.Errorf("injector: %s of type %v doesn't implement %s", name, t, strings.Join(missing, ", ")))
+		}
+	})
+}
+
 // Component registers a new dependency without specifying the name.
 // It's handy for injecting by types.
 // One must be careful when injecting by types as it can cause conflicts easily.
-func (c *Injector) Component(dep interface{}) {
-	c.NamedComponent(c.nextGeneratedName(), dep)
+// The generated name is returned so the component can be retrieved later via Get.
+func (c *Injector) Component(dep interface{}) string {
+	defer annotatePanic("Component", "")
+
+	t := reflect.TypeOf(dep)
+	if t == nil {
+		throw(errUntypedNil)
+	}
+
+	name := c.nextGeneratedName(t)
+	defer annotatePanic("Component", name)
+
+	c.NamedComponent(name, dep)
+	return name
 }
 
 // Inject injects dependencies to a given object. It returns error if there is any.
 // The object should be a pointer of struct, otherwise dependencies won't be injected.
 func (c *Injector) Inject(object interface{}) {
+	defer annotatePanic("Inject", "")
+
 	dep := &dependency{
 		value:        object,
 		reflectType:  reflect.TypeOf(object),
@@ -158,16 +598,137 @@ func (c *Injector) Inject(object interface{}) {
 	}
 
 	if err := c.populate(dep); err != nil {
-		panic(err)
+		throw(err)
 	}
 }
 
+// TryInject is similar to Inject, instead errors are returned rather than panicking,
+// and an object which isn't a non-nil pointer to a struct is reported instead of being silently ignored.
+// Inject is kept as is for compatibility.
+func (c *Injector) TryInject(object interface{}) error {
+	if t := reflect.TypeOf(object); t == nil || !isStructPtr(t) || reflect.ValueOf(object).IsNil() {
+		return fmt.Errorf("injector: Inject requires a pointer to struct, got %v", t)
+	}
+
+	return Run(func() {
+		c.Inject(object)
+	})
+}
+
+// InjectInto injects named dependencies into exported fields of object, which must be a pointer to a struct.
+// fields maps field names to names of dependencies and struct tags are ignored entirely,
+// so third-party structs which can't be annotated can be wired as well.
+// Fields are validated before any of them is injected, an error is returned if a field doesn't exist,
+// is unexported or isn't assignable from its dependency.
+func (c *Injector) InjectInto(object interface{}, fields map[string]string) error {
+	return Run(func() {
+		t := reflect.TypeOf(object)
+		if t == nil || !isStructPtr(t) {
+			throw(fmt.Errorf("injector: %v is not injectable, a pointer to a struct is expected", t))
+		}
+
+		fieldNames := make([]string, 0, len(fields))
+		for fieldName := range fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+
+		// values are assigned to copies first, so the object is left untouched if any field is invalid
+		structValue := reflect.ValueOf(object).Elem()
+		fieldValues := make([]reflect.Value, len(fieldNames))
+		values := make([]reflect.Value, len(fieldNames))
+		for i, fieldName := range fieldNames {
+			structField, found := t.Elem().FieldByName(fieldName)
+			if !found {
+				throw(fmt.Errorf("injector: %s has no field %s", t.Elem(), fieldName))
+			}
+
+			fieldValue, err := structValue.FieldByIndexErr(structField.Index)
+			if err != nil {
+				throw(fmt.Errorf("injector: %s of %s can't be injected: %w", fieldName, t.Elem(), err))
+			}
+
+			fieldValues[i] = fieldValue
+			if !structField.IsExported() || !fieldValue.CanSet() {
+				throw(fmt.Errorf("injector: %s of %s is unexported and can't be injected", fieldName, t.Elem()))
+			}
+
+			dep, err := c.loadDepForTag(injectTag{name: fields[fieldName]}, structField.Type)
+			if err != nil {
+				throw(err)
+			}
+
+			values[i] = reflect.New(structField.Type).Elem()
+			if err := c.assignField(values[i], dep); err != nil {
+				throw(fmt.Errorf("injector: %s of %s can't be injected: %w", fieldName, t.Elem(), err))
+			}
+		}
+
+		for i, fieldValue := range fieldValues {
+			fieldValue.Set(values[i])
+		}
+	})
+}
+
+func (c *Injector) register(name string, dep *dependency) {
+	if dep.reflectType == nil {
+		throw(fmt.Errorf("injector: %s is an untyped nil, a typed value is expected", name))
+	}
+
+	if err := c.prepare(name, dep); err != nil {
+		throw(err)
+	}
+
+	dep.name = name
+	c.dependencies[name] = dep
+	c.names = append(c.names, name)
+	c.debug("injector: registered component", "name", name, "type", dep.reflectType)
+	c.notifyRegistered(name)
+}
+
+// prepare injects dependencies into a newly created component and initializes it.
+func (c *Injector) prepare(name string, dep *dependency) error {
+	if err := c.populate(dep); err != nil {
+		c.debug("injector: failed to register component", "name", name, "type", dep.reflectType, "error", err)
+		return err
+	}
+
+	if err := c.initialize(name, dep); err != nil {
+		c.debug("injector: failed to initialize component", "name", name, "type", dep.reflectType, "error", err)
+		return err
+	}
+
+	return c.afterInject(name, dep)
+}
+
 func (c *Injector) populate(dep *dependency) error {
+	if dep.reflectType == nil {
+		return nil
+	}
+
 	if !isStructPtr(dep.reflectType) {
-		if hasInjectTag(dep) {
+		if dep.reflectType.Kind() != reflect.Struct {
+			return nil
+		}
+
+		tagged, found := c.taggedTypes[dep.reflectType]
+		if !found {
+			tagged = hasInjectTag(dep, c.tagKeys)
+			c.taggedTypes[dep.reflectType] = tagged
+		}
+
+		if !tagged {
+			return nil
+		}
+
+		if !dep.reflectValue.CanAddr() {
 			return fmt.Errorf("injector: %s is not injectable, a pointer is expected", dep.reflectType)
 		}
 
+		return c.populateAddressable(dep)
+	}
+
+	if dep.reflectValue.IsNil() {
 		return nil
 	}
 
@@ -176,117 +737,387 @@ func (c *Injector) populate(dep *dependency) error {
 		fieldType := fieldValue.Type()
 		structField := dep.reflectType.Elem().Field(i)
 		fieldTag := structField.Tag
-		tagValue, ok := fieldTag.Lookup("injector")
+		tagValue, ok := lookupTag(fieldTag, c.tagKeys)
 		if !ok {
 			continue
 		}
 
-		loadedDep, err := c.loadDepForTag(tagValue, fieldType)
+		// embedded fields are injected as other fields, e.g. an embedded interface,
+		// but they can't be set if their types are unexported
+		if !fieldValue.CanSet() {
+			return fmt.Errorf("injector: %s of %s is unexported and can't be injected", structField.Name, dep.reflectType.Elem())
+		}
+
+		tag := parseTag(tagValue)
+		if tag.has(ifEmptyOption) && !fieldValue.IsZero() {
+			continue
+		}
+
+		if tag.name == selfInjectionTag {
+			if err := c.assignField(fieldValue, dep); err != nil {
+				return err
+			}
+
+			c.recordWiring(dep, structField.Name, selfInjectionTag)
+			continue
+		}
+
+		defaultDep, hasDefault := c.lookupDefault(structField.Name)
+		if c.isOptionalMissing(tag) && !hasDefault {
+			c.debug("injector: skipped missing optional field", "field", structField.Name, "tag", tagValue)
+			continue
+		}
+
+		loadedDep, err := c.loadDepForTag(tag, fieldType)
 		if err != nil {
-			return err
+			if !hasDefault || !isNotFound(err) {
+				return err
+			}
+
+			loadedDep = defaultDep
 		}
 
-		if !loadedDep.reflectType.AssignableTo(fieldType) {
-			return fmt.Errorf("injector: %s is not assignable from %s", fieldType, loadedDep.reflectType)
+		if err := c.assignField(fieldValue, loadedDep); err != nil {
+			return err
 		}
 
-		fieldValue.Set(loadedDep.reflectValue)
+		dep.dependsOn = append(dep.dependsOn, loadedDep.references()...)
+		c.recordWiring(dep, structField.Name, loadedDep.describe())
+		c.debug("injector: injected field", "field", structField.Name, "tag", tagValue, "type", loadedDep.reflectType)
 	}
 
 	return nil
 }
 
-func (c *Injector) loadDepForTag(tag string, t reflect.Type) (*dependency, error) {
-	if tag == autoInjectionTag {
-		return c.findByType(t)
+// populateAddressable injects dependencies into an addressable struct value via its address.
+func (c *Injector) populateAddressable(dep *dependency) error {
+	ptrDep := &dependency{
+		value:        dep.reflectValue.Addr().Interface(),
+		reflectType:  reflect.PointerTo(dep.reflectType),
+		reflectValue: dep.reflectValue.Addr(),
 	}
 
-	loadedDep, found := c.dependencies[tag]
-	if !found {
-		return nil, fmt.Errorf("injector: %s is not registered", tag)
+	if err := c.populate(ptrDep); err != nil {
+		return err
 	}
 
-	return loadedDep, nil
+	dep.value = dep.reflectValue.Interface()
+	dep.dependsOn = append(dep.dependsOn, ptrDep.dependsOn...)
+	dep.wiring = ptrDep.wiring
+	return nil
 }
 
-func (c *Injector) executeFunc(fn interface{}, fnType reflect.Type) (*dependency, error) {
-	if fnType.NumOut() > 2 || fnType.NumOut() < 1 {
-		return nil, errors.New("injector: unsupported factory function")
+// assignField sets the field to the given dependency. A field of type **T is filled
+// with a new pointer to the *T dependency as the stored dependency isn't addressable.
+func (c *Injector) assignField(fieldValue reflect.Value, dep *dependency) error {
+	fieldType := fieldValue.Type()
+	if c.matcher(dep.reflectType, fieldType) {
+		v, err := convertValue(dep.reflectValue, fieldType)
+		if err != nil {
+			return err
+		}
+
+		fieldValue.Set(v)
+		return nil
 	}
 
-	if fnType.NumOut() == 2 && !implementsError(fnType.Out(1)) {
-		return nil, errors.New("injector: 2nd output param must implement error")
+	if isPtrToPtr(fieldType) && dep.reflectType.AssignableTo(fieldType.Elem()) {
+		ptr := reflect.New(fieldType.Elem())
+		ptr.Elem().Set(dep.reflectValue)
+		fieldValue.Set(ptr)
+		return nil
+	}
+
+	if isPtrToInterface(fieldType) {
+		return errPointerToInterface(fieldType)
+	}
+
+	return fmt.Errorf("injector: %s is not assignable from %s", fieldType, dep.reflectType)
+}
+
+func (c *Injector) loadDepForTag(tag injectTag, t reflect.Type) (*dependency, error) {
+	if alternatives := tag.alternatives(); len(alternatives) > 1 {
+		var err error
+		for _, alternative := range alternatives {
+			var loadedDep *dependency
+			if loadedDep, err = c.loadDepForTag(alternative, t); err == nil {
+				return loadedDep, nil
+			}
+		}
+
+		return nil, err
+	}
+
+	name, err := c.interpolate(tag.name)
+	if err != nil {
+		return nil, err
+	}
+
+	tag.name = name
+	if tag.name == autoInjectionTag {
+		if tag.has(compositeOption) {
+			return c.combine(t)
+		}
+
+		name, dep, err := c.findByType(t)
+		if err != nil && isPtrToPtr(t) {
+			name, dep, err = c.findByType(t.Elem())
+		}
+
+		if err != nil && tag.has(createOption) && isNotFound(err) {
+			return c.createStruct(t)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return c.resolve(name, dep)
+	}
+
+	if tag.name == configInjectionTag && tag.has(prefixOption) {
+		return c.loadConfig(tag.options[prefixOption], c.optional && !tag.has(requiredOption), t)
+	}
+
+	if isGroupTag(tag) {
+		return c.collectGroup(tag, t)
+	}
+
+	if isGroupNamesTag(tag) {
+		return c.collectGroupNames(strings.TrimPrefix(tag.name, groupNamesPrefix), t)
+	}
+
+	if owner := c.collectionOwner(tag.name); owner != nil {
+		return owner.assembleCollection(tag.name, t)
+	}
+
+	loadedDep, found := c.lookup(tag.name)
+	if !found {
+		return nil, errNotFound("injector: %s is not registered", tag.name)
+	}
+
+	return c.resolve(tag.name, loadedDep)
+}
+
+func (c *Injector) executeFunc(fn interface{}, fnType reflect.Type, overrides []interface{}) (*dependency, error) {
+	if err := validateFactoryOutputs(fnType); err != nil {
+		return nil, err
 	}
 
-	fnVal := reflect.ValueOf(fn)
-	inParams, err := c.generateInParams(fnType)
+	inParams, dependsOn, err := c.generateInParams(fnType, overrides)
 	if err != nil {
 		return nil, err
 	}
 
-	out := fnVal.Call(inParams)
+	return callFactory(fn, fnType, inParams, dependsOn)
+}
+
+func validateFactoryOutputs(fnType reflect.Type) error {
+	if fnType.NumOut() > 2 || fnType.NumOut() < 1 {
+		return errors.New("injector: unsupported factory function")
+	}
+
+	if fnType.NumOut() == 2 && !implementsError(fnType.Out(1)) {
+		return errors.New("injector: 2nd output param must implement error")
+	}
+
+	return nil
+}
+
+// callFactory invokes a factory function with the given parameters and creates a dependency from its output.
+func callFactory(fn interface{}, fnType reflect.Type, inParams []reflect.Value, dependsOn []string) (*dependency, error) {
+	out := reflect.ValueOf(fn).Call(inParams)
 	if len(out) == 2 && !out[1].IsNil() {
 		return nil, out[1].Interface().(error)
 	}
 
+	created := out[0]
+	if created.Kind() == reflect.Struct {
+		// an addressable copy allows injecting dependencies into the returned struct
+		created = reflect.New(created.Type()).Elem()
+		created.Set(out[0])
+	}
+
 	newDep := &dependency{
-		value:        out[0].Interface(),
-		reflectValue: out[0],
-		reflectType:  out[0].Type(),
+		value:        created.Interface(),
+		reflectValue: created,
+		reflectType:  created.Type(),
+		factoryType:  fnType,
+		dependsOn:    dependsOn,
 	}
 
 	return newDep, nil
 }
 
-func (c *Injector) generateInParams(fnType reflect.Type) ([]reflect.Value, error) {
+// generateInParams resolves parameters of a function. It also returns names of resolved components.
+func (c *Injector) generateInParams(fnType reflect.Type, overrides []interface{}) ([]reflect.Value, []string, error) {
 	params := make([]reflect.Value, fnType.NumIn())
+	names := []string{}
 	for i := 0; i < fnType.NumIn(); i++ {
-		param, err := c.findByType(fnType.In(i))
+		override, found, err := findOverride(overrides, fnType.In(i))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		params[i] = param.reflectValue
+		if found {
+			params[i] = override
+			continue
+		}
+
+		name, param, err := c.findByType(fnType.In(i))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		param, err = c.resolve(name, param)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		c.debug("injector: resolved parameter", "name", name, "type", param.reflectType)
+
+		params[i], err = convertValue(param.reflectValue, fnType.In(i))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		names = append(names, name)
 	}
 
-	return params, nil
+	return params, names, nil
 }
 
-func (c *Injector) findByType(t reflect.Type) (*dependency, error) {
-	var foundVal *dependency
-	for _, v := range c.dependencies {
-		if v.reflectType.AssignableTo(t) {
-			if foundVal != nil {
-				return nil, fmt.Errorf("injector: there is a conflict when finding the dependency for %s", t.String())
+// candidatesOf returns components matching t in registration order, an exact match takes precedence
+// over other matches. With WithResolutionOrder, only the first or the last match is returned.
+func (c *Injector) candidatesOf(t reflect.Type) []*dependency {
+	candidates := []*dependency{}
+	c.forEach(func(v *dependency) {
+		if c.matcher(v.reflectType, t) {
+			candidates = append(candidates, v)
+		}
+	})
+
+	if len(candidates) > 1 {
+		exactMatches := []*dependency{}
+		for _, candidate := range candidates {
+			if candidate.reflectType == t {
+				exactMatches = append(exactMatches, candidate)
 			}
+		}
+
+		if len(exactMatches) == 1 {
+			return exactMatches
+		}
+	}
 
-			foundVal = v
+	if len(candidates) > 1 {
+		switch c.resolutionOrder {
+		case ResolutionOrderFirst:
+			return candidates[:1]
+		case ResolutionOrderLast:
+			return candidates[len(candidates)-1:]
 		}
 	}
 
+	return candidates
+}
+
+// findByType finds the component for t as follows:
+//  1. Components assignable to t are matches, e.g. components whose types implement t if t is an interface.
+//  2. If there is only one match, it's found.
+//  3. If there is more than one match but only one of them is exactly of type t, e.g. an interface registered
+//     via RegisterValue or RegisterPlaceholder, the exact match is found as it's the most specific one.
+//  4. With WithResolutionOrder, the first or the last registered match is found.
+//  5. Otherwise, more than one match is a conflict, while no match falls back to adapters
+//     and then to the fallback Injector.
+//
+// Registration order only affects the order of matches listed in conflict errors.
+func (c *Injector) findByType(t reflect.Type) (string, *dependency, error) {
+	if c.noAutoInjection {
+		return "", nil, errNoAutoInjection(t)
+	}
+
+	candidates := c.candidatesOf(t)
+	if len(candidates) > 1 {
+		return "", nil, errConflict(t, candidates)
+	}
+
+	var foundVal *dependency
+	if len(candidates) == 1 {
+		foundVal = candidates[0]
+	}
+
 	if foundVal == nil {
-		return nil, fmt.Errorf("injector: couldn't find the dependency for %s", t.String())
+		adaptedName, adapted, err := c.adapt(t)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if adapted != nil {
+			return adaptedName, adapted, nil
+		}
+
+		if c.fallback != nil {
+			return c.fallback.findByType(t)
+		}
+
+		if isPtrToInterface(t) {
+			return "", nil, errPointerToInterface(t)
+		}
+
+		return "", nil, errNotFound("injector: couldn't find the dependency for %s", t.String())
 	}
 
-	return foundVal, nil
+	return foundVal.name, foundVal, nil
 }
 
-func (c *Injector) nextGeneratedName() string {
+func (c *Injector) nextGeneratedName(t reflect.Type) string {
 	for {
-		newName := fmt.Sprintf("%s.%d", unnamedPrefix, c.unnamedCounter)
-		if _, ok := c.dependencies[newName]; !ok {
+		newName := c.nameGenerator(t, c.unnamedCounter)
+		if !c.isRegistered(newName) {
 			return newName
 		}
 		c.unnamedCounter++
 	}
 }
 
+// Freeze marks the Injector immutable, any registration after that panics.
+// It enforces wiring everything before running the application, a late registration usually indicates a bug.
+// Get and Inject still work after the Injector is frozen.
+func (c *Injector) Freeze() {
+	defer c.recordOperation("Freeze")()
+
+	c.frozen = true
+}
+
+func (c *Injector) validateFrozen() {
+	if c.readOnly {
+		throw(errors.New("injector: a composite container is read-only"))
+	}
+
+	if c.frozen {
+		throw(errors.New("injector: container is frozen"))
+	}
+}
+
 func (c *Injector) validateNamne(name string) {
-	if _, found := c.dependencies[name]; found {
-		panic(fmt.Errorf("injector: %s is already registered", name))
+	c.validateFrozen()
+
+	if c.isRegistered(name) {
+		throw(fmt.Errorf("injector: %s is already registered", name))
 	}
 
-	if name == autoInjectionTag {
-		panic(fmt.Errorf("injector: %s is revserved, please use a different name", autoInjectionTag))
+	if name == autoInjectionTag || name == selfInjectionTag {
+		throw(fmt.Errorf("injector: %s is revserved, please use a different name", name))
 	}
 }
+
+func defaultNameGenerator(_ reflect.Type, index int) string {
+	return fmt.Sprintf("%s.%d", unnamedPrefix, index)
+}
+
+func (c *Injector) isRegistered(name string) bool {
+	_, isDependency := c.dependencies[name]
+	_, isCollection := c.collections[name]
+	return isDependency || isCollection
+}
@@ -2,7 +2,10 @@ package injector
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -110,6 +113,21 @@ func Test_ComponentFromFunc(t *testing.T) {
 		})
 	})
 
+	t.Run("nil-func", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: a factory function is expected", func() {
+			c.ComponentFromFunc(nil)
+		})
+
+		require.PanicsWithError(t, "injector: a factory function is expected", func() {
+			c.NamedComponentFromFunc("nil-func", nil)
+		})
+
+		require.PanicsWithError(t, "injector: a factory function is expected", func() {
+			c.NamedComponentsFromFunc([]string{"nil-func"}, nil)
+		})
+	})
+
 	t.Run("too-many-out-params", func(t *testing.T) {
 		c := New()
 		mockFunc := func() (int, int, error) {
@@ -171,7 +189,7 @@ func Test_ComponentFromFunc(t *testing.T) {
 
 		c.NamedComponent("string-dep-1", "dep-1")
 		c.NamedComponent("string-dep-2", "dep-2")
-		require.PanicsWithError(t, "injector: there is a conflict when finding the dependency for string", func() {
+		require.PanicsWithError(t, "injector: there is a conflict when finding the dependency for string: [string-dep-1 (string), string-dep-2 (string)]", func() {
 			c.ComponentFromFunc(mockFunc)
 		})
 	})
@@ -231,7 +249,8 @@ func Test_NamedComponent_reserved_name(t *testing.T) {
 
 func Test_Component(t *testing.T) {
 	c := New()
-	c.Component(10)
+	name := c.Component(10)
+	require.Equal(t, "unnamed.0", name)
 	require.Len(t, c.dependencies, 1)
 	require.NotNil(t, c.dependencies["unnamed.0"])
 	require.Equal(t, 0, c.unnamedCounter)
@@ -240,8 +259,25 @@ func Test_Component(t *testing.T) {
 func Test_Component_taken(t *testing.T) {
 	c := New()
 	c.NamedComponent("unnamed.0", 10)
-	c.Component(11)
-	require.EqualValues(t, 11, c.Get("unnamed.1"))
+	name := c.Component(11)
+	require.Equal(t, "unnamed.1", name)
+	require.EqualValues(t, 11, c.Get(name))
+}
+
+func Test_ComponentFromFunc_name(t *testing.T) {
+	c := New()
+	name := c.ComponentFromFunc(func() int {
+		return 10
+	})
+	require.EqualValues(t, 10, c.Get(name))
+}
+
+func Test_ComponentFromFactory_name(t *testing.T) {
+	c := New()
+	name := c.ComponentFromFactory(&mockFactory{
+		mockResult: "newObject",
+	})
+	require.Equal(t, "newObject", c.Get(name))
 }
 
 func Test_Inject(t *testing.T) {
@@ -295,3 +331,988 @@ func Test_ComponentFromFactory_inject_failed(t *testing.T) {
 		c.ComponentFromFactory(&mockFactoryWithInjection{})
 	})
 }
+
+func Test_RegisterValue(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		c := New()
+		a := &TypeA{}
+		c.NamedComponent("mocked-int", 10)
+		c.RegisterValue("type-a", reflect.ValueOf(a))
+		require.EqualValues(t, 10, a.Field)
+		require.Equal(t, a, c.Get("type-a"))
+	})
+
+	t.Run("invalid-value", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: an invalid reflect.Value can't be registered", func() {
+			c.RegisterValue("invalid", reflect.Value{})
+		})
+	})
+
+	t.Run("unexported-value", func(t *testing.T) {
+		c := New()
+		v := reflect.ValueOf(struct{ field int }{field: 10}).Field(0)
+		require.PanicsWithError(t, "injector: int is obtained via unexported fields and can't be registered", func() {
+			c.RegisterValue("unexported", v)
+		})
+	})
+
+	t.Run("duplicate-registration", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		require.PanicsWithError(t, "injector: mocked-int is already registered", func() {
+			c.RegisterValue("mocked-int", reflect.ValueOf(11))
+		})
+	})
+}
+
+func Test_NamedComponentsFromFunc(t *testing.T) {
+	t.Run("distinct-instances", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		invoked := 0
+		c.NamedComponentsFromFunc([]string{"pool-a", "pool-b"}, func() *TypeA {
+			invoked++
+			return &TypeA{}
+		})
+
+		poolA := c.Get("pool-a").(*TypeA)
+		poolB := c.Get("pool-b").(*TypeA)
+		require.Equal(t, 2, invoked)
+		require.NotSame(t, poolA, poolB)
+		require.Equal(t, 10, poolA.Field)
+		require.Equal(t, 10, poolB.Field)
+	})
+
+	t.Run("already-registered", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("pool-b", 1)
+		require.PanicsWithError(t, "injector: pool-b is already registered", func() {
+			c.NamedComponentsFromFunc([]string{"pool-a", "pool-b"}, func() *TypeA {
+				return &TypeA{}
+			})
+		})
+		require.False(t, c.isRegistered("pool-a"))
+	})
+
+	t.Run("duplicated", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: pool-a is duplicated", func() {
+			c.NamedComponentsFromFunc([]string{"pool-a", "pool-a"}, func() *TypeA {
+				return &TypeA{}
+			})
+		})
+		require.False(t, c.isRegistered("pool-a"))
+	})
+}
+
+func Test_NamedComponentFromFuncWith(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("string-dep", "from-injector")
+		c.NamedComponent("mocked-int", 10)
+		mockFunc := func(s string, v int) string {
+			return fmt.Sprintf("%s-%d", s, v)
+		}
+
+		c.NamedComponentFromFuncWith("result", mockFunc, "from-override")
+		require.Equal(t, "from-override-10", c.Get("result"))
+	})
+
+	t.Run("conflict-override", func(t *testing.T) {
+		c := New()
+		mockFunc := func(s string) string {
+			return s
+		}
+
+		require.PanicsWithError(t, "injector: there is a conflict when finding the override for string", func() {
+			c.NamedComponentFromFuncWith("result", mockFunc, "override-1", "override-2")
+		})
+	})
+}
+
+func Test_ResolveAuto(t *testing.T) {
+	t.Run("single", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("string-dep", "dep")
+		name, err := c.ResolveAuto(reflect.TypeOf(0))
+		require.NoError(t, err)
+		require.Equal(t, "mocked-int", name)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int-1", 10)
+		c.NamedComponent("mocked-int-2", 11)
+		name, err := c.ResolveAuto(reflect.TypeOf(0))
+		require.EqualError(t, err, "injector: there is a conflict when finding the dependency for int: [mocked-int-1 (int), mocked-int-2 (int)]")
+		require.Empty(t, name)
+	})
+
+	t.Run("conflict-interface", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mock-renderer", mockRenderer("mock"))
+		c.NamedComponent("renderer-impl", &rendererImpl{})
+		_, err := c.ResolveAuto(reflectTypeOfRenderer)
+		require.EqualError(t, err, "injector: there is a conflict when finding the dependency for injector.Renderer: [mock-renderer (injector.mockRenderer), renderer-impl (*injector.rendererImpl)]")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		c := New()
+		name, err := c.ResolveAuto(reflect.TypeOf(0))
+		require.EqualError(t, err, "injector: couldn't find the dependency for int")
+		require.Empty(t, name)
+	})
+}
+
+type TypeE struct {
+	Named **TypeA `injector:"type-a"`
+	Auto  **TypeA `injector:"auto"`
+}
+
+func Test_NamedComponent_double_pointer(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		c := New()
+		a := &TypeA{}
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("type-a", a)
+		e := &TypeE{}
+		c.NamedComponent("type-e", e)
+		require.NotNil(t, e.Named)
+		require.Equal(t, a, *e.Named)
+		require.NotNil(t, e.Auto)
+		require.Equal(t, a, *e.Auto)
+	})
+
+	t.Run("not-assignable", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("type-a", "not-type-a")
+		require.PanicsWithError(t, "injector: **injector.TypeA is not assignable from string", func() {
+			c.NamedComponent("type-e", &TypeE{})
+		})
+	})
+
+	t.Run("conflict-dependency", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("type-a", &TypeA{})
+		c.NamedComponent("another-type-a", &TypeA{})
+		require.PanicsWithError(t, "injector: there is a conflict when finding the dependency for *injector.TypeA: [type-a (*injector.TypeA), another-type-a (*injector.TypeA)]", func() {
+			c.Inject(&struct {
+				Auto **TypeA `injector:"auto"`
+			}{})
+		})
+	})
+}
+
+type HandlerFunc func(string) error
+
+func Test_NamedComponent_func_type(t *testing.T) {
+	mockHandler := func(s string) error {
+		return errors.New(s)
+	}
+
+	t.Run("by-type", func(t *testing.T) {
+		c := New()
+		c.Component(mockHandler)
+		c.Component(func(s string) {})
+		target := &struct {
+			Handler      func(string) error `injector:"auto"`
+			NamedHandler HandlerFunc        `injector:"auto"`
+		}{}
+		c.Inject(target)
+		require.EqualError(t, target.Handler("handled"), "handled")
+		require.EqualError(t, target.NamedHandler("handled"), "handled")
+	})
+
+	t.Run("by-name", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("handler", HandlerFunc(mockHandler))
+		target := &struct {
+			Handler func(string) error `injector:"handler"`
+		}{}
+		c.Inject(target)
+		require.EqualError(t, target.Handler("handled"), "handled")
+	})
+
+	t.Run("signature-mismatch", func(t *testing.T) {
+		c := New()
+		c.Component(mockHandler)
+		require.PanicsWithError(t, "injector: couldn't find the dependency for func(string)", func() {
+			c.Inject(&struct {
+				Handler func(string) `injector:"auto"`
+			}{})
+		})
+	})
+}
+
+type Renderer interface {
+	Render() string
+}
+
+type rendererImpl struct{}
+
+func (r *rendererImpl) Render() string {
+	return "rendered"
+}
+
+func Test_NamedComponent_nil(t *testing.T) {
+	t.Run("untyped-nil", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: renderer is an untyped nil, a typed value is expected", func() {
+			c.NamedComponent("renderer", nil)
+		})
+	})
+
+	t.Run("typed-nil", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("renderer", (*rendererImpl)(nil))
+		c.NamedComponent("type-a", (*TypeA)(nil))
+		target := &struct {
+			Renderer Renderer `injector:"renderer"`
+			TypeA    *TypeA   `injector:"type-a"`
+		}{}
+		c.Inject(target)
+		require.True(t, target.Renderer != nil, "typed nil component must be injected as is")
+		require.Nil(t, target.Renderer.(*rendererImpl))
+		require.Nil(t, target.TypeA)
+	})
+
+	t.Run("real-value", func(t *testing.T) {
+		c := New()
+		c.Component(&rendererImpl{})
+		target := &struct {
+			Renderer Renderer `injector:"auto"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, "rendered", target.Renderer.Render())
+	})
+
+	t.Run("inject-untyped-nil", func(t *testing.T) {
+		c := New()
+		require.NotPanics(t, func() {
+			c.Inject(nil)
+		})
+	})
+}
+
+func Test_GetByPrefix(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("handler.users", 1)
+		c.NamedComponent("handler.orders", 2)
+		c.NamedComponent("service.users", 3)
+		require.Equal(t, map[string]interface{}{
+			"handler.users":  1,
+			"handler.orders": 2,
+		}, c.GetByPrefix("handler."))
+	})
+
+	t.Run("no-match", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("service.users", 3)
+		require.Empty(t, c.GetByPrefix("handler."))
+		require.NotNil(t, c.GetByPrefix("handler."))
+	})
+}
+
+func Test_Freeze(t *testing.T) {
+	c := New()
+	c.NamedComponent("mocked-int", 10)
+	c.ProvideInto("renderers", func() *rendererImpl {
+		return &rendererImpl{}
+	})
+	c.Freeze()
+
+	registrations := map[string]func(){
+		"named-component": func() { c.NamedComponent("type-a", &TypeA{}) },
+		"component":       func() { c.Component(&TypeA{}) },
+		"register-value":  func() { c.RegisterValue("type-a", reflect.ValueOf(&TypeA{})) },
+		"from-func":       func() { c.ComponentFromFunc(func() int { return 1 }) },
+		"from-factory":    func() { c.ComponentFromFactory(&mockFactory{mockResult: 1}) },
+		"provide-into": func() {
+			c.ProvideInto("renderers", func() *rendererImpl { return &rendererImpl{} })
+		},
+	}
+
+	for name, register := range registrations {
+		t.Run(name, func(t *testing.T) {
+			require.PanicsWithError(t, "injector: container is frozen", register)
+		})
+	}
+
+	t.Run("get-and-inject", func(t *testing.T) {
+		require.EqualValues(t, 10, c.Get("mocked-int"))
+		d := &TypeD{}
+		c.Inject(d)
+		require.Equal(t, 10, d.Field)
+	})
+}
+
+func Test_NamedComponent_value_types(t *testing.T) {
+	c := New()
+	now := time.Now()
+	c.NamedComponent("now", now)
+	c.NamedComponent("later", now.Add(time.Hour))
+	require.Equal(t, now, c.Get("now"))
+	require.Len(t, c.taggedTypes, 1, "tags of a struct type must be scanned once")
+}
+
+func Benchmark_NamedComponent_values(b *testing.B) {
+	now := time.Now()
+	for i := 0; i < b.N; i++ {
+		c := New()
+		for j := 0; j < 100; j++ {
+			c.NamedComponent(fmt.Sprintf("int.%d", j), j)
+			c.NamedComponent(fmt.Sprintf("string.%d", j), "value")
+			c.NamedComponent(fmt.Sprintf("time.%d", j), now)
+		}
+	}
+}
+
+func Test_findByType_registration_order(t *testing.T) {
+	c := New()
+	c.NamedComponent("int-c", 3)
+	c.NamedComponent("int-a", 1)
+	c.NamedComponent("string-b", "2")
+	require.Equal(t, []string{"int-c", "int-a", "string-b"}, c.names)
+
+	for i := 0; i < 10; i++ {
+		_, err := c.ResolveAuto(reflect.TypeOf(0))
+		require.EqualError(t, err, "injector: there is a conflict when finding the dependency for int: [int-c (int), int-a (int)]")
+	}
+}
+
+func Test_NamedComponent_pointer_to_interface(t *testing.T) {
+	t.Run("by-name", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("renderer", &rendererImpl{})
+		require.PanicsWithError(t, "injector: *injector.Renderer is a pointer to an interface, use injector.Renderer as the field type instead", func() {
+			c.Inject(&struct {
+				Renderer *Renderer `injector:"renderer"`
+			}{})
+		})
+	})
+
+	t.Run("by-type", func(t *testing.T) {
+		c := New()
+		c.Component(&rendererImpl{})
+		require.PanicsWithError(t, "injector: *injector.Renderer is a pointer to an interface, use injector.Renderer as the field type instead", func() {
+			c.Inject(&struct {
+				Renderer *Renderer `injector:"auto"`
+			}{})
+		})
+	})
+
+	t.Run("registered-pointer-to-interface", func(t *testing.T) {
+		c := New()
+		var renderer Renderer = &rendererImpl{}
+		c.Component(&renderer)
+		target := &struct {
+			Renderer *Renderer `injector:"auto"`
+		}{}
+		c.Inject(target)
+		require.Same(t, &renderer, target.Renderer)
+	})
+}
+
+func Test_Inject_ifempty(t *testing.T) {
+	c := New()
+	c.NamedComponent("mocked-int", 10)
+	c.NamedComponent("renderer", &rendererImpl{})
+
+	type target struct {
+		Field    int      `injector:"auto,ifempty"`
+		Renderer Renderer `injector:"renderer,ifempty"`
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		empty := &target{}
+		c.Inject(empty)
+		require.Equal(t, 10, empty.Field)
+		require.Equal(t, "rendered", empty.Renderer.Render())
+	})
+
+	t.Run("pre-populated", func(t *testing.T) {
+		populated := &target{
+			Field:    1,
+			Renderer: mockRenderer("manual"),
+		}
+		c.Inject(populated)
+		require.Equal(t, 1, populated.Field)
+		require.Equal(t, "manual", populated.Renderer.Render())
+	})
+}
+
+func Test_NamedComponentFromFunc_struct_value(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponentFromFunc("type-a", func() TypeA {
+			return TypeA{}
+		})
+		require.Equal(t, TypeA{Field: 10}, c.Get("type-a"))
+
+		target := &struct {
+			TypeA TypeA `injector:"auto"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, 10, target.TypeA.Field)
+	})
+
+	t.Run("missing-dependency", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: mocked-int is not registered", func() {
+			c.NamedComponentFromFunc("type-a", func() TypeA {
+				return TypeA{}
+			})
+		})
+	})
+}
+
+func Test_Inject_empty_interface(t *testing.T) {
+	t.Run("by-name", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("string-dep", "dep")
+		target := &struct {
+			Field interface{} `injector:"mocked-int"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, 10, target.Field)
+	})
+
+	t.Run("auto-single", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		target := &struct {
+			Field interface{} `injector:"auto"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, 10, target.Field)
+	})
+
+	t.Run("auto-conflict", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("string-dep", "dep")
+		require.PanicsWithError(t, "injector: there is a conflict when finding the dependency for interface {}, any component is assignable to it, please inject it by name", func() {
+			c.Inject(&struct {
+				Field interface{} `injector:"auto"`
+			}{})
+		})
+	})
+}
+
+type treeNode struct {
+	Self     *treeNode `injector:"self"`
+	Children []*treeNode
+}
+
+func Test_NamedComponent_self(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		c := New()
+		node := &treeNode{}
+		c.NamedComponent("root", node)
+		require.Same(t, node, node.Self)
+		require.Same(t, c.Get("root"), node.Self)
+	})
+
+	t.Run("not-assignable", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: int is not assignable from *struct { Field int \"injector:\\\"self\\\"\" }", func() {
+			c.Component(&struct {
+				Field int `injector:"self"`
+			}{})
+		})
+	})
+
+	t.Run("reserved-name", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: self is revserved, please use a different name", func() {
+			c.NamedComponent("self", 10)
+		})
+	})
+}
+
+func Test_Inject_fallback(t *testing.T) {
+	type withFallback struct {
+		Renderer Renderer `injector:"renderer|auto"`
+	}
+
+	t.Run("first-alternative", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("renderer", mockRenderer("named"))
+		c.NamedComponent("other", &rendererImpl{})
+
+		target := &withFallback{}
+		c.Inject(target)
+		require.Equal(t, "named", target.Renderer.Render())
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("other", mockRenderer("auto"))
+
+		target := &withFallback{}
+		c.Inject(target)
+		require.Equal(t, "auto", target.Renderer.Render())
+	})
+
+	t.Run("all-failed", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: couldn't find the dependency for injector.Renderer", func() {
+			c.Inject(&withFallback{})
+		})
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		c := New(WithOptionalByDefault())
+		c.NamedComponent("mocked-int", 10)
+
+		target := &struct {
+			Field   int `injector:"missing-int|mocked-int"`
+			Missing int `injector:"missing-int|other-int"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, 10, target.Field)
+		require.Zero(t, target.Missing)
+	})
+}
+
+func Test_NamedComponentUnique(t *testing.T) {
+	c := New()
+	c.NamedComponent("mocked-int", 10)
+
+	t.Run("unique", func(t *testing.T) {
+		require.NoError(t, c.NamedComponentUnique("type-a", &TypeA{}))
+		require.Equal(t, 10, c.Get("type-a").(*TypeA).Field)
+	})
+
+	t.Run("same-type", func(t *testing.T) {
+		require.EqualError(t, c.NamedComponentUnique("other-int", 20), "injector: other-int has the same type int as mocked-int")
+		require.False(t, c.isRegistered("other-int"))
+	})
+
+	t.Run("registration-error", func(t *testing.T) {
+		require.EqualError(t, c.NamedComponentUnique("mocked-int", "text"), "injector: mocked-int is already registered")
+	})
+}
+
+type unexportedRenderer interface {
+	Render() string
+}
+
+func Test_Inject_embedded_interface(t *testing.T) {
+	c := New()
+	c.NamedComponent("renderer", mockRenderer("embedded"))
+
+	t.Run("by-name", func(t *testing.T) {
+		target := &struct {
+			Renderer `injector:"renderer"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, "embedded", target.Render())
+	})
+
+	t.Run("by-type", func(t *testing.T) {
+		target := &struct {
+			Renderer `injector:"auto"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, "embedded", target.Render())
+	})
+
+	t.Run("unexported", func(t *testing.T) {
+		type withUnexported struct {
+			unexportedRenderer `injector:"auto"`
+		}
+
+		require.PanicsWithError(t, "injector: unexportedRenderer of injector.withUnexported is unexported and can't be injected", func() {
+			c.Inject(&withUnexported{})
+		})
+	})
+}
+
+func Test_IsAmbiguous(t *testing.T) {
+	c := New()
+	require.False(t, c.IsAmbiguous(reflectTypeOfRenderer))
+
+	c.NamedComponent("renderer-1", mockRenderer("1"))
+	require.False(t, c.IsAmbiguous(reflectTypeOfRenderer))
+
+	c.NamedComponent("renderer-2", &rendererImpl{})
+	require.True(t, c.IsAmbiguous(reflectTypeOfRenderer))
+	require.False(t, c.IsAmbiguous(reflect.TypeOf(&rendererImpl{})))
+
+	var renderer Renderer = mockRenderer("interface")
+	c.RegisterValue("as-interface", reflect.ValueOf(&renderer).Elem())
+	require.False(t, c.IsAmbiguous(reflectTypeOfRenderer))
+
+	t.Run("fallback", func(t *testing.T) {
+		host := New()
+		host.NamedComponent("renderer-1", mockRenderer("1"))
+		host.NamedComponent("renderer-2", &rendererImpl{})
+		plugin := New()
+		plugin.SetFallback(host)
+		require.True(t, plugin.IsAmbiguous(reflectTypeOfRenderer))
+
+		plugin.NamedComponent("renderer", mockRenderer("plugin"))
+		require.False(t, plugin.IsAmbiguous(reflectTypeOfRenderer))
+	})
+
+	t.Run("adapters", func(t *testing.T) {
+		c := New()
+		c.RegisterAdapter(adaptLegacyPrinter)
+		c.NamedComponent("printer-1", &legacyPrinter{text: "1"})
+		require.False(t, c.IsAmbiguous(reflectTypeOfRenderer))
+
+		c.NamedComponent("printer-2", &legacyPrinter{text: "2"})
+		require.True(t, c.IsAmbiguous(reflectTypeOfRenderer))
+	})
+}
+
+func Test_TryInject(t *testing.T) {
+	c := New()
+	c.NamedComponent("mocked-int", 10)
+
+	t.Run("pointer", func(t *testing.T) {
+		target := &TypeA{}
+		require.NoError(t, c.TryInject(target))
+		require.Equal(t, 10, target.Field)
+	})
+
+	t.Run("value", func(t *testing.T) {
+		require.EqualError(t, c.TryInject(TypeA{}), "injector: Inject requires a pointer to struct, got injector.TypeA")
+	})
+
+	t.Run("non-struct-pointer", func(t *testing.T) {
+		n := 1
+		require.EqualError(t, c.TryInject(&n), "injector: Inject requires a pointer to struct, got *int")
+		require.EqualError(t, c.TryInject(nil), "injector: Inject requires a pointer to struct, got <nil>")
+	})
+
+	t.Run("nil-pointer", func(t *testing.T) {
+		require.EqualError(t, c.TryInject((*TypeA)(nil)), "injector: Inject requires a pointer to struct, got *injector.TypeA")
+	})
+
+	t.Run("injection-error", func(t *testing.T) {
+		require.EqualError(t, New().TryInject(&TypeA{}), "injector: mocked-int is not registered")
+	})
+}
+
+func Test_InjectInto(t *testing.T) {
+	type thirdParty struct {
+		Renderer Renderer
+		Count    int
+		name     string
+	}
+
+	c := New()
+	c.NamedComponent("renderer", &rendererImpl{})
+	c.NamedComponent("mocked-int", 10)
+	c.NamedComponent("mocked-string", "name")
+
+	t.Run("injected", func(t *testing.T) {
+		target := &thirdParty{}
+		require.NoError(t, c.InjectInto(target, map[string]string{
+			"Renderer": "renderer",
+			"Count":    "mocked-int",
+		}))
+		require.Equal(t, "rendered", target.Renderer.Render())
+		require.Equal(t, 10, target.Count)
+	})
+
+	t.Run("missing-field", func(t *testing.T) {
+		target := &thirdParty{}
+		err := c.InjectInto(target, map[string]string{
+			"Count":   "mocked-int",
+			"Missing": "mocked-int",
+		})
+		require.EqualError(t, err, "injector: injector.thirdParty has no field Missing")
+		require.Zero(t, target.Count)
+	})
+
+	t.Run("unexported-field", func(t *testing.T) {
+		err := c.InjectInto(&thirdParty{}, map[string]string{"name": "mocked-string"})
+		require.EqualError(t, err, "injector: name of injector.thirdParty is unexported and can't be injected")
+	})
+
+	t.Run("not-assignable", func(t *testing.T) {
+		target := &thirdParty{}
+		err := c.InjectInto(target, map[string]string{
+			"Renderer": "renderer",
+			"Count":    "mocked-string",
+		})
+		require.EqualError(t, err, "injector: Count of injector.thirdParty can't be injected: injector: int is not assignable from string")
+		require.Nil(t, target.Renderer)
+	})
+
+	t.Run("not-registered", func(t *testing.T) {
+		err := c.InjectInto(&thirdParty{}, map[string]string{"Count": "missing"})
+		require.EqualError(t, err, "injector: missing is not registered")
+	})
+
+	t.Run("not-struct-pointer", func(t *testing.T) {
+		err := c.InjectInto(thirdParty{}, map[string]string{"Count": "mocked-int"})
+		require.EqualError(t, err, "injector: injector.thirdParty is not injectable, a pointer to a struct is expected")
+	})
+}
+
+func Test_AssertImplements(t *testing.T) {
+	c := New()
+	c.NamedComponent("renderer", &rendererImpl{})
+	var component interface{} = mockRenderer("interface")
+	c.RegisterValue("as-interface", reflect.ValueOf(&component).Elem())
+	c.NamedComponent("mocked-int", 10)
+
+	t.Run("implemented", func(t *testing.T) {
+		require.NoError(t, c.AssertImplements("renderer", (*Renderer)(nil)))
+		require.NoError(t, c.AssertImplements("as-interface", (*Renderer)(nil)))
+	})
+
+	t.Run("missing-interfaces", func(t *testing.T) {
+		err := c.AssertImplements("mocked-int", (*Renderer)(nil), (*fmt.Stringer)(nil))
+		require.EqualError(t, err, "injector: mocked-int of type int doesn't implement injector.Renderer, fmt.Stringer")
+	})
+
+	t.Run("not-pointer-to-interface", func(t *testing.T) {
+		require.EqualError(t, c.AssertImplements("renderer", &rendererImpl{}), "injector: *injector.rendererImpl is not a pointer to an interface")
+		require.EqualError(t, c.AssertImplements("renderer", nil), "injector: <nil> is not a pointer to an interface")
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		require.EqualError(t, c.AssertImplements("missing", (*Renderer)(nil)), "injector: the requested dependency couldn't be found")
+	})
+}
+
+func Test_findByType_interfaces(t *testing.T) {
+	t.Run("implementation", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("renderer-impl", &rendererImpl{})
+		name, err := c.ResolveAuto(reflectTypeOfRenderer)
+		require.NoError(t, err)
+		require.Equal(t, "renderer-impl", name)
+	})
+
+	t.Run("pointer-receiver", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("renderer-value", rendererImpl{})
+		c.NamedComponent("renderer-ptr", &rendererImpl{})
+
+		name, err := c.ResolveAuto(reflectTypeOfRenderer)
+		require.NoError(t, err)
+		require.Equal(t, "renderer-ptr", name)
+
+		name, err = c.ResolveAuto(reflect.TypeOf(rendererImpl{}))
+		require.NoError(t, err)
+		require.Equal(t, "renderer-value", name)
+	})
+
+	t.Run("interface-and-concrete-matches", func(t *testing.T) {
+		var renderer Renderer = mockRenderer("interface")
+		c := New()
+		c.RegisterValue("as-interface", reflect.ValueOf(&renderer).Elem())
+		c.NamedComponent("as-concrete", &rendererImpl{})
+
+		name, err := c.ResolveAuto(reflectTypeOfRenderer)
+		require.NoError(t, err)
+		require.Equal(t, "as-interface", name)
+
+		name, err = c.ResolveAuto(reflect.TypeOf(&rendererImpl{}))
+		require.NoError(t, err)
+		require.Equal(t, "as-concrete", name)
+	})
+
+	t.Run("several-exact-matches", func(t *testing.T) {
+		var renderer Renderer = mockRenderer("interface")
+		c := New()
+		c.RegisterValue("as-interface-1", reflect.ValueOf(&renderer).Elem())
+		c.RegisterValue("as-interface-2", reflect.ValueOf(&renderer).Elem())
+		c.NamedComponent("as-concrete", &rendererImpl{})
+
+		_, err := c.ResolveAuto(reflectTypeOfRenderer)
+		require.EqualError(t, err, "injector: there is a conflict when finding the dependency for injector.Renderer: [as-interface-1 (injector.Renderer), as-interface-2 (injector.Renderer), as-concrete (*injector.rendererImpl)]")
+	})
+
+	t.Run("exact-named-func-type", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("unnamed-func", func(string) error { return nil })
+		c.NamedComponent("handler-func", HandlerFunc(func(string) error { return nil }))
+
+		name, err := c.ResolveAuto(reflect.TypeOf(HandlerFunc(nil)))
+		require.NoError(t, err)
+		require.Equal(t, "handler-func", name)
+	})
+
+	t.Run("placeholder", func(t *testing.T) {
+		c := New()
+		c.RegisterPlaceholder("placeholder", (*Renderer)(nil))
+		c.NamedComponent("concrete", mockRenderer("concrete"))
+
+		name, err := c.ResolveAuto(reflectTypeOfRenderer)
+		require.NoError(t, err)
+		require.Equal(t, "placeholder", name)
+	})
+
+	t.Run("registered-as-interface", func(t *testing.T) {
+		var renderer Renderer = mockRenderer("interface")
+		c := New()
+		c.RegisterValue("as-interface", reflect.ValueOf(&renderer).Elem())
+
+		name, err := c.ResolveAuto(reflectTypeOfRenderer)
+		require.NoError(t, err)
+		require.Equal(t, "as-interface", name)
+
+		_, err = c.ResolveAuto(reflect.TypeOf(mockRenderer("")))
+		require.EqualError(t, err, "injector: couldn't find the dependency for injector.mockRenderer")
+	})
+}
+
+func Test_NamedComponentIfAvailable(t *testing.T) {
+	type withAccelerator struct {
+		Accelerator Renderer `injector:"accelerator"`
+	}
+
+	t.Run("available", func(t *testing.T) {
+		c := New()
+		probed := 0
+		c.NamedComponentIfAvailable("accelerator", func() (interface{}, bool) {
+			probed++
+			return mockRenderer("gpu"), true
+		})
+
+		target := &withAccelerator{}
+		c.Inject(target)
+		require.Equal(t, "gpu", target.Accelerator.Render())
+		require.Equal(t, 1, probed)
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		c := New(WithOptionalByDefault())
+		c.NamedComponentIfAvailable("accelerator", func() (interface{}, bool) {
+			return nil, false
+		})
+		require.False(t, c.isRegistered("accelerator"))
+
+		target := &withAccelerator{}
+		c.Inject(target)
+		require.Nil(t, target.Accelerator)
+	})
+
+	t.Run("already-registered", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("accelerator", mockRenderer("cpu"))
+		require.PanicsWithError(t, "injector: accelerator is already registered", func() {
+			c.NamedComponentIfAvailable("accelerator", func() (interface{}, bool) {
+				return mockRenderer("gpu"), true
+			})
+		})
+	})
+}
+
+func Test_Inject_interpolated(t *testing.T) {
+	type withInterpolation struct {
+		URL string `injector:"db.{env}.url"`
+	}
+
+	c := New()
+	c.NamedComponent("env", "prod")
+	c.NamedComponent("db.prod.url", "postgres://prod")
+	c.NamedComponent("db.dev.url", "postgres://dev")
+
+	t.Run("interpolated", func(t *testing.T) {
+		target := &withInterpolation{}
+		c.Inject(target)
+		require.Equal(t, "postgres://prod", target.URL)
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		target := &struct {
+			URL string `injector:"db.{region}.url|db.{env}.url"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, "postgres://prod", target.URL)
+	})
+
+	t.Run("missing-source", func(t *testing.T) {
+		c := New(WithOptionalByDefault())
+		require.PanicsWithError(t, "injector: env used in db.{env}.url is not registered", func() {
+			c.Inject(&withInterpolation{})
+		})
+	})
+
+	t.Run("not-string", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("env", 10)
+		require.PanicsWithError(t, "injector: env used in db.{env}.url is int, a string is expected", func() {
+			c.Inject(&withInterpolation{})
+		})
+	})
+
+	t.Run("interpolated-missing", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("env", "staging")
+		require.PanicsWithError(t, "injector: db.staging.url is not registered", func() {
+			c.Inject(&withInterpolation{})
+		})
+	})
+}
+
+func Test_NamedComponentWith(t *testing.T) {
+	type server struct {
+		Field   int `injector:"mocked-int"`
+		Host    string
+		Timeout time.Duration
+		Handler HandlerFunc
+	}
+
+	newServer := func(host string, timeout time.Duration, handler HandlerFunc) (*server, error) {
+		return &server{Host: host, Timeout: timeout, Handler: handler}, nil
+	}
+
+	c := New()
+	c.NamedComponent("mocked-int", 10)
+
+	t.Run("happy-path", func(t *testing.T) {
+		c.NamedComponentWith("server", newServer, "localhost", 5, nil)
+		s := c.Get("server").(*server)
+		require.Equal(t, "localhost", s.Host)
+		require.Equal(t, time.Duration(5), s.Timeout)
+		require.Nil(t, s.Handler)
+		require.Equal(t, 10, s.Field)
+	})
+
+	t.Run("invalid-arguments", func(t *testing.T) {
+		require.PanicsWithError(t, "injector: 1 arguments are given while 3 are expected", func() {
+			c.NamedComponentWith("server-1", newServer, "localhost")
+		})
+
+		require.PanicsWithError(t, "injector: argument 1: string is not assignable to time.Duration", func() {
+			c.NamedComponentWith("server-2", newServer, "localhost", "5s", nil)
+		})
+
+		require.PanicsWithError(t, "injector: argument 0: nil is not assignable to string", func() {
+			c.NamedComponentWith("server-3", newServer, nil, 5, nil)
+		})
+	})
+
+	t.Run("invalid-function", func(t *testing.T) {
+		require.PanicsWithError(t, "injector: a non-variadic function is expected", func() {
+			c.NamedComponentWith("server-4", "not-a-func")
+		})
+
+		require.PanicsWithError(t, "injector: unsupported factory function", func() {
+			c.NamedComponentWith("server-5", func() {})
+		})
+	})
+
+	t.Run("error", func(t *testing.T) {
+		require.PanicsWithError(t, "random error", func() {
+			c.NamedComponentWith("server-6", func() (*server, error) {
+				return nil, errors.New("random error")
+			})
+		})
+	})
+}
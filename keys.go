@@ -0,0 +1,65 @@
+package injector
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// KeyedComponent registers a component identified by a comparable key instead of a name,
+// e.g. a typed constant, to avoid typos in names of components. Keys of different types are different
+// even if they have the same underlying value.
+// The component is registered under a generated name in the form of "<type of key>(<key>)" which
+// is returned, so it can be injected by name or by type as other components.
+func (c *Injector) KeyedComponent(key interface{}, dep interface{}) string {
+	defer annotatePanic("KeyedComponent", "")
+	defer c.recordOperation("KeyedComponent", key, dep)()
+
+	validateKey(key)
+	name := fmt.Sprintf("%T(%v)", key, key)
+	if _, found := c.lookupKey(key); found {
+		throw(fmt.Errorf("injector: %s is already registered", name))
+	}
+
+	c.NamedComponent(name, dep)
+	c.keys[key] = name
+	return name
+}
+
+// GetByKey loads a component registered via KeyedComponent by its key.
+func (c *Injector) GetByKey(key interface{}) interface{} {
+	defer annotatePanic("GetByKey", "")
+
+	validateKey(key)
+	name, found := c.lookupKey(key)
+	if !found {
+		throw(errors.New("injector: the requested dependency couldn't be found"))
+	}
+
+	return c.Get(name)
+}
+
+// lookupKey finds the name of a component by key in the Injector, then in its sources and then in its fallback.
+func (c *Injector) lookupKey(key interface{}) (string, bool) {
+	if name, found := c.keys[key]; found {
+		return name, true
+	}
+
+	for _, source := range c.sources {
+		if name, found := source.lookupKey(key); found {
+			return name, true
+		}
+	}
+
+	if c.fallback != nil {
+		return c.fallback.lookupKey(key)
+	}
+
+	return "", false
+}
+
+func validateKey(key interface{}) {
+	if key == nil || !reflect.ValueOf(key).Comparable() {
+		throw(fmt.Errorf("injector: %T isn't comparable and can't be used as a key", key))
+	}
+}
@@ -0,0 +1,62 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type databaseKey int
+
+const (
+	databasePrimary databaseKey = iota
+	databaseReplica
+)
+
+func Test_KeyedComponent(t *testing.T) {
+	c := New()
+	require.Equal(t, "injector.databaseKey(0)", c.KeyedComponent(databasePrimary, "primary"))
+	c.KeyedComponent(databaseReplica, "replica")
+	c.KeyedComponent(0, "zero")
+
+	t.Run("get-by-key", func(t *testing.T) {
+		require.Equal(t, "primary", c.GetByKey(databasePrimary))
+		require.Equal(t, "replica", c.GetByKey(databaseReplica))
+		require.Equal(t, "zero", c.GetByKey(0))
+		require.Equal(t, "primary", NewComposite(c).GetByKey(databasePrimary))
+	})
+
+	t.Run("inject-by-name", func(t *testing.T) {
+		target := &struct {
+			Primary string `injector:"injector.databaseKey(0)"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, "primary", target.Primary)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		require.PanicsWithError(t, "injector: the requested dependency couldn't be found", func() {
+			c.GetByKey(databaseKey(2))
+		})
+	})
+
+	t.Run("duplicated", func(t *testing.T) {
+		require.PanicsWithError(t, "injector: injector.databaseKey(0) is already registered", func() {
+			c.KeyedComponent(databasePrimary, "other")
+		})
+	})
+
+	t.Run("not-comparable", func(t *testing.T) {
+		require.PanicsWithError(t, "injector: []string isn't comparable and can't be used as a key", func() {
+			c.KeyedComponent([]string{"key"}, "other")
+		})
+
+		require.PanicsWithError(t, "injector: <nil> isn't comparable and can't be used as a key", func() {
+			c.KeyedComponent(nil, "other")
+		})
+
+		require.PanicsWithError(t, "injector: []int isn't comparable and can't be used as a key", func() {
+			c.GetByKey([]int{1})
+		})
+	})
+}
@@ -0,0 +1,51 @@
+package injector
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Module registers a group of related components to an Injector,
+// e.g. all components of a feature, so wiring can be split into composable parts.
+type Module interface {
+	Register(c *Injector) error
+}
+
+// Apply registers modules to the Injector in order. Errors returned by modules and panics raised by
+// the Injector while registering are collected, each prefixed with the type of the module, and joined
+// into the returned error. Remaining modules are still applied if a module fails.
+func (c *Injector) Apply(modules ...Module) error {
+	errs := []error{}
+	for _, module := range modules {
+		err := Run(func() {
+			if err := module.Register(c); err != nil {
+				throw(err)
+			}
+		})
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("injector: module %T: %w", module, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// RegisterDiscovered registers components returned by discover, e.g. implementations enumerated by a plugin scan,
+// each via Component with a generated name. It decouples discovering components from registering them.
+// Errors of registrations are collected, each prefixed with the index and the type of the component,
+// and joined into the returned error. Remaining components are still registered if a component fails.
+func (c *Injector) RegisterDiscovered(discover func() []interface{}) error {
+	errs := []error{}
+	for i, component := range discover() {
+		err := Run(func() {
+			c.Component(component)
+		})
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("injector: discovered component %d (%T): %w", i, component, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,84 @@
+package injector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type intModule struct{}
+
+func (intModule) Register(c *Injector) error {
+	c.NamedComponent("mocked-int", 10)
+	return nil
+}
+
+type typeAModule struct{}
+
+func (typeAModule) Register(c *Injector) error {
+	c.NamedComponent("type-a", &TypeA{})
+	return nil
+}
+
+type failedModule struct {
+	err error
+}
+
+func (m failedModule) Register(c *Injector) error {
+	return m.err
+}
+
+func Test_Apply(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		c := New()
+		require.NoError(t, c.Apply(intModule{}, typeAModule{}))
+		require.Equal(t, 10, c.Get("type-a").(*TypeA).Field)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		c := New()
+		randomErr := errors.New("random error")
+		err := c.Apply(typeAModule{}, failedModule{err: randomErr}, intModule{})
+		require.EqualError(t, err, "injector: module injector.typeAModule: injector: mocked-int is not registered\n"+
+			"injector: module injector.failedModule: random error")
+		require.True(t, errors.Is(err, randomErr))
+		require.Equal(t, 10, c.Get("mocked-int"))
+	})
+
+	t.Run("no-modules", func(t *testing.T) {
+		require.NoError(t, New().Apply())
+	})
+}
+
+func Test_RegisterDiscovered(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		err := c.RegisterDiscovered(func() []interface{} {
+			return []interface{}{mockRenderer("a"), &TypeA{}, "discovered"}
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "a", GetByType[Renderer](c).Render())
+		require.Equal(t, 10, GetByType[*TypeA](c).Field)
+		require.Equal(t, "discovered", GetByType[string](c))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		c := New()
+		err := c.RegisterDiscovered(func() []interface{} {
+			return []interface{}{&TypeA{}, nil, mockRenderer("a")}
+		})
+
+		require.EqualError(t, err, "injector: discovered component 0 (*injector.TypeA): injector: mocked-int is not registered\n"+
+			"injector: discovered component 1 (<nil>): injector: an untyped nil can't be registered without a name, a typed value is expected")
+		require.Equal(t, "a", GetByType[Renderer](c).Render())
+	})
+
+	t.Run("nothing-discovered", func(t *testing.T) {
+		require.NoError(t, New().RegisterDiscovered(func() []interface{} {
+			return nil
+		}))
+	})
+}
@@ -0,0 +1,175 @@
+package injector
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+)
+
+// Option defines an option to customize an Injector.
+type Option func(c *Injector)
+
+// WithLogger sets a logger for the Injector. Registrations, resolutions and errors
+// are logged at debug level with the name and the type of components involved.
+// Nothing is logged by default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Injector) {
+		c.logger = logger
+	}
+}
+
+// WithNameGenerator sets the function generating names for components registered without names,
+// e.g. via Component or ComponentFromFunc. The function receives the type of the component and an index,
+// generated names are checked for uniqueness and the index is increased until an unused name is generated.
+// The type is never nil as untyped nils and invalid factory functions are rejected before names are generated.
+// Therefore, the function must generate different names for different indexes.
+// By default, names are generated in the form of "unnamed.<index>".
+func WithNameGenerator(fn func(t reflect.Type, index int) string) Option {
+	return func(c *Injector) {
+		c.nameGenerator = fn
+	}
+}
+
+// WithAfterInject adds a callback which is invoked after each component is injected and initialized,
+// including components created by factories. It's a central place to register components
+// with external systems, e.g. health checks, based on interfaces they implement.
+func WithAfterInject(fn func(name string, component interface{})) Option {
+	return WithAfterInjectE(func(name string, component interface{}) error {
+		fn(name, component)
+		return nil
+	})
+}
+
+// WithAfterInjectE is similar to WithAfterInject, instead the registration fails
+// if the callback returns an error.
+func WithAfterInjectE(fn func(name string, component interface{}) error) Option {
+	return func(c *Injector) {
+		c.afterInjects = append(c.afterInjects, fn)
+	}
+}
+
+func (c *Injector) afterInject(name string, dep *dependency) error {
+	for _, fn := range c.afterInjects {
+		if err := fn(name, dep.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WithNoAutoInjection forbids injecting dependencies by types. Fields tagged with `injector:"auto"`
+// and parameters of factory functions which aren't satisfied by overrides result in errors,
+// so every dependency has to be injected explicitly by name.
+func WithNoAutoInjection() Option {
+	return func(c *Injector) {
+		c.noAutoInjection = true
+	}
+}
+
+// WithTagKeys sets the keys of struct tags which are looked up for injection, e.g. "injector" and "inject"
+// to support both conventions while migrating from one to another. If a field has tags with multiple keys,
+// the tag whose key is listed first is used. By default, only the "injector" key is used.
+func WithTagKeys(keys ...string) Option {
+	return func(c *Injector) {
+		c.tagKeys = keys
+	}
+}
+
+// WithMatcher sets the function deciding whether a component of depType can be injected into
+// a field or a factory parameter of fieldType. It's used when injecting by types, when assigning
+// named components to fields, and when matching components of combiners, adapters, groups,
+// collections and ComponentsOfType. A matched component which isn't assignable is converted to fieldType,
+// e.g. to inject an int component into a time.Duration field.
+// By default, a component matches if it's assignable, i.e. reflect.Type.AssignableTo.
+// A loose matcher may cause conflicts or unexpected injections, and matched types which
+// aren't convertible result in errors, so it should be used with care.
+func WithMatcher(fn func(depType, fieldType reflect.Type) bool) Option {
+	return func(c *Injector) {
+		c.matcher = fn
+	}
+}
+
+// ResolutionOrder decides which component is injected by type when more than one component matches.
+type ResolutionOrder int
+
+const (
+	// ResolutionOrderConflict reports a conflict if more than one component matches, it's the default.
+	ResolutionOrderConflict ResolutionOrder = iota
+	// ResolutionOrderFirst picks the first registered component among matches.
+	ResolutionOrderFirst
+	// ResolutionOrderLast picks the last registered component among matches.
+	ResolutionOrderLast
+)
+
+// WithResolutionOrder picks a component by registration order when more than one component matches a type
+// instead of reporting a conflict. A component registered exactly as the type still takes precedence.
+// It's a simpler alternative to naming dependencies explicitly, but it changes the conflict-error default,
+// so a component registered accidentally is silently injected or ignored. It should be used deliberately.
+func WithResolutionOrder(order ResolutionOrder) Option {
+	return func(c *Injector) {
+		c.resolutionOrder = order
+	}
+}
+
+// WithFastMode skips bookkeeping which is only needed to debug the Injector, reducing memory allocated
+// at registration by about a third: registrations aren't recorded, so Recording returns nothing,
+// and Wiring returns no fields. Dependencies are injected and validated as usual.
+func WithFastMode() Option {
+	return func(c *Injector) {
+		c.fastMode = true
+	}
+}
+
+// WithOptionalByDefault leaves a field at its zero value if its named dependency isn't registered
+// instead of failing. A field can opt into strictness with the required option,
+// e.g. `injector:"logger,required"`. Fields of configs whose components aren't registered are left
+// at their zero values as well, unless the config is required, e.g. `injector:"config,prefix=db,required"`.
+// Dependencies injected by types and placeholders which aren't fulfilled still result in errors.
+// As it may mask wiring mistakes such as typos in names, it's intended for prototyping,
+// enable WithLogger to see which fields are skipped.
+func WithOptionalByDefault() Option {
+	return func(c *Injector) {
+		c.optional = true
+	}
+}
+
+// isOptionalMissing returns true if the field tagged with tag can be skipped
+// as its named dependency isn't registered.
+// With alternatives, it's skipped only if none of them is registered.
+func (c *Injector) isOptionalMissing(tag injectTag) bool {
+	if !c.optional || tag.has(requiredOption) {
+		return false
+	}
+
+	for _, alternative := range tag.alternatives() {
+		name, err := c.interpolate(alternative.name)
+		if err != nil {
+			return false
+		}
+
+		alternative.name = name
+		if alternative.name == autoInjectionTag || isGroupTag(alternative) || isGroupNamesTag(alternative) ||
+			(alternative.name == configInjectionTag && tag.has(prefixOption)) {
+			return false
+		}
+
+		if c.collectionOwner(alternative.name) != nil {
+			return false
+		}
+
+		if _, found := c.lookup(alternative.name); found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *Injector) debug(msg string, args ...interface{}) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.Log(context.Background(), slog.LevelDebug, msg, args...)
+}
@@ -0,0 +1,387 @@
+package injector
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	c := New(WithLogger(logger))
+	require.Panics(t, func() {
+		c.NamedComponent("type-d", &TypeD{})
+	})
+	c.NamedComponent("mocked-int", 10)
+	c.NamedComponent("type-a", &TypeA{})
+	c.ComponentFromFunc(func(a *TypeA) *TypeB {
+		return &TypeB{Field: a}
+	})
+
+	require.Equal(t, `level=DEBUG msg="injector: failed to register component" name=type-d type=*injector.TypeD error="injector: couldn't find the dependency for int"
+level=DEBUG msg="injector: registered component" name=mocked-int type=int
+level=DEBUG msg="injector: injected field" field=Field tag=mocked-int type=int
+level=DEBUG msg="injector: registered component" name=type-a type=*injector.TypeA
+level=DEBUG msg="injector: resolved parameter" name=type-a type=*injector.TypeA
+level=DEBUG msg="injector: injected field" field=Field tag=type-a type=*injector.TypeA
+level=DEBUG msg="injector: registered component" name=unnamed.0 type=*injector.TypeB
+`, buf.String())
+}
+
+func Test_WithNameGenerator(t *testing.T) {
+	c := New(WithNameGenerator(func(t reflect.Type, index int) string {
+		return fmt.Sprintf("%s#%d", t, index)
+	}))
+
+	c.NamedComponent("int#0", 0)
+	require.Equal(t, "int#1", c.Component(1))
+	require.Equal(t, "string#1", c.ComponentFromFunc(func() string { return "value" }))
+	require.Equal(t, "*injector.rendererImpl#1", c.ComponentFromFactory(&mockFactory{mockResult: &rendererImpl{}}))
+	require.EqualValues(t, 1, c.Get("int#1"))
+
+	require.PanicsWithError(t, "injector: an untyped nil can't be registered without a name, a typed value is expected", func() {
+		c.Component(nil)
+	})
+	require.PanicsWithError(t, "injector: a factory function is expected", func() {
+		c.ComponentFromFunc(10)
+	})
+}
+
+func Test_WithAfterInject(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		injected := map[string]interface{}{}
+		c := New(WithAfterInject(func(name string, component interface{}) {
+			injected[name] = component
+		}))
+
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponentFromFunc("type-a", func() *TypeA {
+			return &TypeA{}
+		})
+		name := c.ComponentFromFactory(&mockFactory{mockResult: "created"})
+		require.Equal(t, map[string]interface{}{
+			"mocked-int": 10,
+			"type-a":     &TypeA{Field: 10},
+			name:         "created",
+		}, injected)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := New(WithAfterInjectE(func(name string, component interface{}) error {
+			return fmt.Errorf("%s is rejected", name)
+		}))
+
+		require.PanicsWithError(t, "mocked-int is rejected", func() {
+			c.NamedComponent("mocked-int", 10)
+		})
+		require.Empty(t, c.GetByPrefix(""))
+	})
+}
+
+func Test_WithNoAutoInjection(t *testing.T) {
+	c := New(WithNoAutoInjection())
+	c.NamedComponent("mocked-int", 10)
+
+	t.Run("named", func(t *testing.T) {
+		a := &TypeA{}
+		c.Inject(a)
+		require.Equal(t, 10, a.Field)
+	})
+
+	t.Run("auto-field", func(t *testing.T) {
+		require.PanicsWithError(t, "injector: injecting int by type is disabled by WithNoAutoInjection, please inject it by name", func() {
+			c.Inject(&TypeD{})
+		})
+	})
+
+	t.Run("factory-params", func(t *testing.T) {
+		require.PanicsWithError(t, "injector: injecting int by type is disabled by WithNoAutoInjection, please inject it by name", func() {
+			c.ComponentFromFunc(func(v int) string {
+				return "created"
+			})
+		})
+	})
+
+	t.Run("factory-overrides", func(t *testing.T) {
+		c.NamedComponentFromFuncWith("created", func(v int) int {
+			return v + 1
+		}, 1)
+		require.EqualValues(t, 2, c.Get("created"))
+	})
+
+	t.Run("composite", func(t *testing.T) {
+		c.RegisterCombiner(combineRenderers)
+		require.PanicsWithError(t, "injector: injecting injector.Renderer by type is disabled by WithNoAutoInjection, please inject it by name", func() {
+			c.Inject(&struct {
+				Renderer Renderer `injector:"auto,composite"`
+			}{})
+		})
+	})
+}
+
+func Test_WithOptionalByDefault(t *testing.T) {
+	type optionalDeps struct {
+		Logger   Renderer `injector:"logger"`
+		Field    int      `injector:"mocked-int"`
+		Required int      `injector:"required-int,required"`
+	}
+
+	t.Run("missing-left-zero", func(t *testing.T) {
+		c := New(WithOptionalByDefault())
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("required-int", 20)
+
+		deps := &optionalDeps{}
+		c.Inject(deps)
+		require.Nil(t, deps.Logger)
+		require.Equal(t, 10, deps.Field)
+		require.Equal(t, 20, deps.Required)
+	})
+
+	t.Run("required-missing", func(t *testing.T) {
+		c := New(WithOptionalByDefault())
+		require.PanicsWithError(t, "injector: required-int is not registered", func() {
+			c.Inject(&optionalDeps{})
+		})
+	})
+
+	t.Run("auto-still-strict", func(t *testing.T) {
+		c := New(WithOptionalByDefault())
+		require.PanicsWithError(t, "injector: couldn't find the dependency for int", func() {
+			c.Inject(&TypeD{})
+		})
+	})
+
+	t.Run("strict-by-default", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("required-int", 20)
+		require.PanicsWithError(t, "injector: logger is not registered", func() {
+			c.Inject(&optionalDeps{})
+		})
+	})
+}
+
+func Test_WithTagKeys(t *testing.T) {
+	type legacyTagged struct {
+		Old   int `inject:"mocked-int"`
+		New   int `injector:"mocked-int"`
+		Both  int `inject:"other-int" injector:"mocked-int"`
+		Plain int
+	}
+
+	t.Run("multiple-keys", func(t *testing.T) {
+		c := New(WithTagKeys("injector", "inject"))
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("other-int", 20)
+
+		target := &legacyTagged{}
+		c.Inject(target)
+		require.Equal(t, legacyTagged{Old: 10, New: 10, Both: 10}, *target)
+	})
+
+	t.Run("precedence", func(t *testing.T) {
+		c := New(WithTagKeys("inject", "injector"))
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("other-int", 20)
+
+		target := &legacyTagged{}
+		c.Inject(target)
+		require.Equal(t, 20, target.Both)
+	})
+
+	t.Run("struct-value", func(t *testing.T) {
+		type legacyValue struct {
+			Old int `inject:"mocked-int"`
+		}
+
+		c := New(WithTagKeys("inject"))
+		c.NamedComponent("mocked-int", 10)
+		require.PanicsWithError(t, "injector: injector.legacyValue is not injectable, a pointer is expected", func() {
+			c.NamedComponent("value", legacyValue{})
+		})
+	})
+
+	t.Run("default", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+
+		target := &legacyTagged{}
+		c.Inject(target)
+		require.Equal(t, legacyTagged{New: 10, Both: 10}, *target)
+	})
+}
+
+func Test_WithMatcher(t *testing.T) {
+	convertibleNumbers := func(depType, fieldType reflect.Type) bool {
+		return depType.AssignableTo(fieldType) ||
+			(depType.Kind() == reflect.Int && fieldType.Kind() == reflect.Int64)
+	}
+
+	t.Run("converted", func(t *testing.T) {
+		c := New(WithMatcher(convertibleNumbers))
+		c.NamedComponent("timeout", 10)
+
+		target := &struct {
+			Named time.Duration `injector:"timeout"`
+			Auto  time.Duration `injector:"auto"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, time.Duration(10), target.Named)
+		require.Equal(t, time.Duration(10), target.Auto)
+
+		name := c.ComponentFromFunc(func(timeout time.Duration) string {
+			return timeout.String()
+		})
+		require.Equal(t, "10ns", c.Get(name))
+	})
+
+	t.Run("adapters-groups-collections", func(t *testing.T) {
+		c := New(WithMatcher(convertibleNumbers))
+		c.NamedComponent("timeout.a", 10)
+		c.NamedComponent("timeout.b", 20)
+		c.ProvideInto("timeouts", func() int { return 30 })
+
+		target := &struct {
+			Group      []time.Duration `injector:"group:timeout.*"`
+			Collection []time.Duration `injector:"timeouts"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, []time.Duration{10, 20}, target.Group)
+		require.Equal(t, []time.Duration{30}, target.Collection)
+		require.Equal(t, []time.Duration{10, 20}, ComponentsOfType[time.Duration](c))
+
+		adapted := New(WithMatcher(convertibleNumbers))
+		adapted.NamedComponent("timeout", 10)
+		adapted.RegisterAdapter(func(d time.Duration) fmt.Stringer {
+			return d
+		})
+		require.Equal(t, "10ns", GetByType[fmt.Stringer](adapted).String())
+	})
+
+	t.Run("not-convertible", func(t *testing.T) {
+		c := New(WithMatcher(func(depType, fieldType reflect.Type) bool {
+			return true
+		}))
+		c.NamedComponent("name", "text")
+		require.PanicsWithError(t, "injector: string is matched with int but it isn't convertible", func() {
+			c.Inject(&struct {
+				Field int `injector:"name"`
+			}{})
+		})
+	})
+
+	t.Run("default", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("timeout", 10)
+		require.PanicsWithError(t, "injector: time.Duration is not assignable from int", func() {
+			c.Inject(&struct {
+				Named time.Duration `injector:"timeout"`
+			}{})
+		})
+	})
+}
+
+func Test_WithResolutionOrder(t *testing.T) {
+	newInjector := func(order ResolutionOrder) *Injector {
+		c := New(WithResolutionOrder(order))
+		c.NamedComponent("renderer-a", mockRenderer("a"))
+		c.NamedComponent("renderer-b", mockRenderer("b"))
+		c.NamedComponent("renderer-c", mockRenderer("c"))
+		return c
+	}
+
+	t.Run("first", func(t *testing.T) {
+		c := newInjector(ResolutionOrderFirst)
+		require.False(t, c.IsAmbiguous(reflectTypeOfRenderer))
+		require.Equal(t, "a", GetByType[Renderer](c).Render())
+	})
+
+	t.Run("last", func(t *testing.T) {
+		c := newInjector(ResolutionOrderLast)
+		name := c.ComponentFromFunc(func(r Renderer) string {
+			return r.Render()
+		})
+		require.Equal(t, "c", c.Get(name))
+	})
+
+	t.Run("exact-match", func(t *testing.T) {
+		c := newInjector(ResolutionOrderLast)
+		var renderer Renderer = mockRenderer("interface")
+		c.RegisterValue("as-interface", reflect.ValueOf(&renderer).Elem())
+		c.NamedComponent("renderer-d", mockRenderer("d"))
+		require.Equal(t, "interface", GetByType[Renderer](c).Render())
+	})
+
+	t.Run("conflict-by-default", func(t *testing.T) {
+		c := newInjector(ResolutionOrderConflict)
+		require.True(t, c.IsAmbiguous(reflectTypeOfRenderer))
+		require.Panics(t, func() {
+			GetByType[Renderer](c)
+		})
+	})
+}
+
+func Test_WithFastMode(t *testing.T) {
+	type taggedValue struct {
+		Field int `injector:"mocked-int"`
+	}
+
+	c := New(WithFastMode())
+	c.NamedComponent("mocked-int", 10)
+	c.NamedComponent("type-a", &TypeA{})
+	c.NamedComponentFromFunc("from-func", func() taggedValue {
+		return taggedValue{}
+	})
+
+	t.Run("injected", func(t *testing.T) {
+		require.Equal(t, 10, c.Get("type-a").(*TypeA).Field)
+		require.Equal(t, taggedValue{Field: 10}, c.Get("from-func"))
+	})
+
+	t.Run("validated", func(t *testing.T) {
+		require.Panics(t, func() {
+			c.NamedComponent("value", taggedValue{})
+		})
+	})
+
+	t.Run("not-recorded", func(t *testing.T) {
+		require.Empty(t, c.Recording())
+		wiring, err := c.Wiring("type-a")
+		require.NoError(t, err)
+		require.Empty(t, wiring)
+	})
+}
+
+func benchmarkRegistration(b *testing.B, opts ...Option) {
+	for i := 0; i < b.N; i++ {
+		c := New(opts...)
+		c.NamedComponent("mocked-int", 10)
+		for j := 0; j < 100; j++ {
+			c.NamedComponent(fmt.Sprintf("config.%d", j), dbConfig{})
+			c.NamedComponent(fmt.Sprintf("type-a.%d", j), &TypeA{})
+		}
+	}
+}
+
+func Benchmark_NamedComponent_default(b *testing.B) {
+	benchmarkRegistration(b)
+}
+
+func Benchmark_NamedComponent_fast_mode(b *testing.B) {
+	benchmarkRegistration(b, WithFastMode())
+}
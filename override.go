@@ -0,0 +1,142 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// snapshot contains the registrations of an Injector at a point in time.
+type snapshot struct {
+	dependencies   map[string]*dependency
+	names          []string
+	collections    map[string]collection
+	keys           map[interface{}]string
+	defaults       map[string]*dependency
+	combiners      map[reflect.Type]reflect.Value
+	adapters       int
+	afterInjects   int
+	onRegisters    int
+	fallback       *Injector
+	unnamedCounter int
+	operations     int
+	frozen         bool
+}
+
+// With registers overrides, replacing registered components with the same names, invokes fn and
+// then restores the Injector to the state before With, even if fn panics. Registrations within fn
+// are removed as well, including components, collections, keys, defaults, combiners, adapters,
+// subscribers of OnRegister and the fallback, and the Injector is unfrozen if fn freezes it.
+// It's handy in tests to run code with a few components swapped.
+// Components created before With keep their dependencies, only resolutions within fn see the overrides.
+func (c *Injector) With(overrides map[string]interface{}, fn func(c *Injector)) {
+	defer annotatePanic("With", "")
+
+	c.validateFrozen()
+	saved := c.snapshot()
+	defer c.restore(saved)
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		c.override(name, overrides[name])
+	}
+
+	fn(c)
+}
+
+func (c *Injector) override(name string, value interface{}) {
+	defer annotatePanic("With", name)
+
+	if _, found := c.collections[name]; found {
+		throw(fmt.Errorf("injector: %s is a collection and can't be overridden", name))
+	}
+
+	dep := &dependency{
+		value:        value,
+		reflectType:  reflect.TypeOf(value),
+		reflectValue: reflect.ValueOf(value),
+	}
+
+	if _, found := c.dependencies[name]; !found {
+		c.validateNamne(name)
+		c.register(name, dep)
+		return
+	}
+
+	if dep.reflectType == nil {
+		throw(fmt.Errorf("injector: %s is an untyped nil, a typed value is expected", name))
+	}
+
+	if err := c.prepare(name, dep); err != nil {
+		throw(err)
+	}
+
+	dep.name = name
+	c.dependencies[name] = dep
+}
+
+func (c *Injector) snapshot() *snapshot {
+	saved := &snapshot{
+		dependencies:   make(map[string]*dependency, len(c.dependencies)),
+		names:          append([]string{}, c.names...),
+		collections:    make(map[string]collection, len(c.collections)),
+		keys:           make(map[interface{}]string, len(c.keys)),
+		defaults:       make(map[string]*dependency, len(c.defaults)),
+		combiners:      make(map[reflect.Type]reflect.Value, len(c.combiners)),
+		adapters:       len(c.adapters),
+		afterInjects:   len(c.afterInjects),
+		onRegisters:    len(c.onRegisters),
+		fallback:       c.fallback,
+		unnamedCounter: c.unnamedCounter,
+		operations:     len(c.operations),
+		frozen:         c.frozen,
+	}
+
+	for name, dep := range c.dependencies {
+		saved.dependencies[name] = dep
+	}
+
+	for name, col := range c.collections {
+		saved.collections[name] = *col
+	}
+
+	for key, name := range c.keys {
+		saved.keys[key] = name
+	}
+
+	for key, dep := range c.defaults {
+		saved.defaults[key] = dep
+	}
+
+	for t, combiner := range c.combiners {
+		saved.combiners[t] = combiner
+	}
+
+	return saved
+}
+
+func (c *Injector) restore(saved *snapshot) {
+	c.dependencies = saved.dependencies
+	c.names = saved.names
+	c.collections = make(map[string]*collection, len(saved.collections))
+	for name, col := range saved.collections {
+		col := col
+		c.collections[name] = &col
+	}
+
+	c.keys = saved.keys
+	c.defaults = saved.defaults
+	c.combiners = saved.combiners
+	c.adapters = c.adapters[:saved.adapters]
+	c.afterInjects = c.afterInjects[:saved.afterInjects]
+	c.onRegisters = c.onRegisters[:saved.onRegisters]
+	c.fallback = saved.fallback
+	c.unnamedCounter = saved.unnamedCounter
+	c.operations = c.operations[:saved.operations]
+	c.frozen = saved.frozen
+}
@@ -0,0 +1,127 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_With(t *testing.T) {
+	newInjector := func() *Injector {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("type-a", &TypeA{})
+		c.ProvideInto("numbers", func(v int) interface{} {
+			return v
+		})
+		return c
+	}
+
+	t.Run("overridden", func(t *testing.T) {
+		c := newInjector()
+		original := c.Get("type-a")
+		c.With(map[string]interface{}{
+			"mocked-int": 20,
+			"new-string": "new",
+		}, func(c *Injector) {
+			require.Equal(t, 20, c.Get("mocked-int"))
+			require.Equal(t, "new", c.Get("new-string"))
+			require.Equal(t, []interface{}{20}, c.Get("numbers"))
+			require.Same(t, original, c.Get("type-a"))
+			c.NamedComponent("type-a-2", &TypeA{})
+			require.Equal(t, 20, c.Get("type-a-2").(*TypeA).Field)
+		})
+
+		require.Equal(t, 10, c.Get("mocked-int"))
+		require.False(t, c.isRegistered("new-string"))
+		require.False(t, c.isRegistered("type-a-2"))
+		require.Equal(t, []string{"mocked-int", "type-a"}, c.names)
+		require.Equal(t, []interface{}{10}, c.Get("numbers"))
+	})
+
+	t.Run("restored-after-panic", func(t *testing.T) {
+		c := newInjector()
+		require.PanicsWithValue(t, "random panic", func() {
+			c.With(map[string]interface{}{"mocked-int": 20}, func(c *Injector) {
+				panic("random panic")
+			})
+		})
+		require.Equal(t, 10, c.Get("mocked-int"))
+	})
+
+	t.Run("invalid-overrides", func(t *testing.T) {
+		c := newInjector()
+		require.PanicsWithError(t, "injector: numbers is a collection and can't be overridden", func() {
+			c.With(map[string]interface{}{"numbers": 20}, func(c *Injector) {})
+		})
+
+		require.PanicsWithError(t, "injector: mocked-int is an untyped nil, a typed value is expected", func() {
+			c.With(map[string]interface{}{"mocked-int": nil}, func(c *Injector) {})
+		})
+
+		require.PanicsWithError(t, "injector: auto is revserved, please use a different name", func() {
+			c.With(map[string]interface{}{"auto": 20}, func(c *Injector) {})
+		})
+		require.Equal(t, 10, c.Get("mocked-int"))
+	})
+
+	t.Run("registrations-restored", func(t *testing.T) {
+		c := newInjector()
+		c.NamedComponent("printer", &legacyPrinter{text: "adapted"})
+		fallback := New()
+		fallback.NamedComponent("mocked-string", "fallback")
+		registered := []string{}
+
+		c.With(nil, func(c *Injector) {
+			c.RegisterAdapter(adaptLegacyPrinter)
+			c.RegisterDefaults(struct{ Timeout int }{Timeout: 5})
+			c.RegisterCombiner(func(renderers []Renderer) Renderer {
+				return renderers[0]
+			})
+			c.OnRegister(func(name string) {
+				registered = append(registered, name)
+			})
+			c.SetFallback(fallback)
+
+			require.Equal(t, "adapted", GetByType[Renderer](c).Render())
+			require.Equal(t, "fallback", c.Get("mocked-string"))
+		})
+
+		require.Panics(t, func() {
+			GetByType[Renderer](c)
+		})
+		require.Panics(t, func() {
+			c.Get("mocked-string")
+		})
+		require.PanicsWithError(t, "injector: Timeout is not registered", func() {
+			c.Inject(&struct {
+				Timeout int `injector:"Timeout"`
+			}{})
+		})
+		require.PanicsWithError(t, "injector: no combiner is registered for injector.Renderer", func() {
+			c.Inject(&struct {
+				Renderer Renderer `injector:"auto,composite"`
+			}{})
+		})
+
+		c.NamedComponent("after-with", 1)
+		require.Empty(t, registered)
+	})
+
+	t.Run("frozen", func(t *testing.T) {
+		c := newInjector()
+		c.Freeze()
+		require.PanicsWithError(t, "injector: container is frozen", func() {
+			c.With(map[string]interface{}{"mocked-int": 20}, func(c *Injector) {})
+		})
+	})
+
+	t.Run("frozen-within", func(t *testing.T) {
+		c := newInjector()
+		c.With(nil, func(c *Injector) {
+			c.Freeze()
+		})
+		c.NamedComponent("after-with", 1)
+		require.Equal(t, 1, c.Get("after-with"))
+	})
+}
@@ -0,0 +1,79 @@
+package injector
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// RegisterPlaceholder reserves a name for a component of an interface type whose value isn't available yet.
+// ifacePtr must be a pointer to the interface, e.g. (*Logger)(nil). The value is provided later via Fulfill.
+// It allows declaring the structure of the wiring first and providing values later,
+// e.g. to break initialization cycles. Injecting an unfulfilled placeholder returns an error.
+func (c *Injector) RegisterPlaceholder(name string, ifacePtr interface{}) {
+	defer annotatePanic("RegisterPlaceholder", name)
+	defer c.recordOperation("RegisterPlaceholder", name, ifacePtr)()
+
+	c.validateNamne(name)
+
+	t := reflect.TypeOf(ifacePtr)
+	if t == nil || !isPtrToInterface(t) {
+		throw(errors.New("injector: a pointer to an interface is expected for a placeholder"))
+	}
+
+	c.dependencies[name] = &dependency{
+		name:        name,
+		reflectType: t.Elem(),
+		placeholder: true,
+	}
+	c.names = append(c.names, name)
+	c.notifyRegistered(name)
+}
+
+// Fulfill provides the value of a placeholder registered via RegisterPlaceholder.
+// The value must implement the interface of the placeholder and its dependencies are injected as usual.
+func (c *Injector) Fulfill(name string, dep interface{}) {
+	defer annotatePanic("Fulfill", name)
+	defer c.recordOperation("Fulfill", name, dep)()
+
+	c.validateFrozen()
+
+	placeholder, found := c.dependencies[name]
+	if !found || !placeholder.placeholder {
+		throw(fmt.Errorf("injector: %s is not a placeholder", name))
+	}
+
+	t := reflect.TypeOf(dep)
+	if t == nil || !t.AssignableTo(placeholder.reflectType) {
+		throw(fmt.Errorf("injector: %s is not assignable from %s", placeholder.reflectType, t))
+	}
+
+	fulfilled := &dependency{
+		value:        dep,
+		reflectType:  t,
+		reflectValue: reflect.ValueOf(dep),
+	}
+
+	if err := c.populate(fulfilled); err != nil {
+		throw(err)
+	}
+
+	if err := c.initialize(name, fulfilled); err != nil {
+		throw(err)
+	}
+
+	if err := c.afterInject(name, fulfilled); err != nil {
+		throw(err)
+	}
+
+	fulfilled.name = name
+	c.dependencies[name] = fulfilled
+}
+
+func validateFulfilled(name string, dep *dependency) error {
+	if dep.placeholder {
+		return fmt.Errorf("injector: %s is a placeholder which isn't fulfilled yet", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package injector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var reflectTypeOfRenderer = reflect.TypeOf((*Renderer)(nil)).Elem()
+
+func Test_RegisterPlaceholder(t *testing.T) {
+	t.Run("fulfilled", func(t *testing.T) {
+		c := New()
+		c.RegisterPlaceholder("renderer", (*Renderer)(nil))
+		name, err := c.ResolveAuto(reflectTypeOfRenderer)
+		require.NoError(t, err)
+		require.Equal(t, "renderer", name)
+
+		c.Fulfill("renderer", &rendererImpl{})
+		target := &struct {
+			Named Renderer `injector:"renderer"`
+			Auto  Renderer `injector:"auto"`
+		}{}
+		c.Inject(target)
+		require.Equal(t, "rendered", target.Named.Render())
+		require.Equal(t, "rendered", target.Auto.Render())
+		require.Equal(t, "rendered", c.Get("renderer").(Renderer).Render())
+	})
+
+	t.Run("unfulfilled", func(t *testing.T) {
+		c := New()
+		c.RegisterPlaceholder("renderer", (*Renderer)(nil))
+		require.PanicsWithError(t, "injector: renderer is a placeholder which isn't fulfilled yet", func() {
+			c.Inject(&struct {
+				Renderer Renderer `injector:"renderer"`
+			}{})
+		})
+		require.PanicsWithError(t, "injector: renderer is a placeholder which isn't fulfilled yet", func() {
+			c.Inject(&struct {
+				Renderer Renderer `injector:"auto"`
+			}{})
+		})
+		require.PanicsWithError(t, "injector: renderer is a placeholder which isn't fulfilled yet", func() {
+			c.ComponentFromFunc(func(r Renderer) string {
+				return r.Render()
+			})
+		})
+		require.PanicsWithError(t, "injector: renderer is a placeholder which isn't fulfilled yet", func() {
+			c.Get("renderer")
+		})
+		require.Empty(t, c.GetByPrefix("renderer"))
+	})
+
+	t.Run("not-interface", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: a pointer to an interface is expected for a placeholder", func() {
+			c.RegisterPlaceholder("renderer", &rendererImpl{})
+		})
+	})
+
+	t.Run("not-assignable", func(t *testing.T) {
+		c := New()
+		c.RegisterPlaceholder("renderer", (*Renderer)(nil))
+		require.PanicsWithError(t, "injector: injector.Renderer is not assignable from string", func() {
+			c.Fulfill("renderer", "not-renderer")
+		})
+	})
+
+	t.Run("not-placeholder", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("renderer", &rendererImpl{})
+		require.PanicsWithError(t, "injector: renderer is not a placeholder", func() {
+			c.Fulfill("renderer", &rendererImpl{})
+		})
+	})
+}
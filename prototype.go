@@ -0,0 +1,83 @@
+package injector
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// NamedPrototypeFromFunc registers a prototype whose instances are created by the factory function.
+// Unlike NamedComponentFromFunc, the factory function isn't invoked at registration, instead a new instance
+// is created every time the prototype is loaded via Get or injected, and dependencies are injected into
+// each instance. It's useful for stateful objects which must not be shared.
+// Instances aren't cached nor tracked by the Injector, so they have to be cleaned up by their users,
+// and missing dependencies of the factory function are only reported when an instance is created.
+func (c *Injector) NamedPrototypeFromFunc(name string, factoryFn interface{}) {
+	defer annotatePanic("NamedPrototypeFromFunc", name)
+	defer c.recordOperation("NamedPrototypeFromFunc", name, factoryFn)()
+
+	c.validateNamne(name)
+
+	fnType := reflect.TypeOf(factoryFn)
+	if fnType == nil || fnType.Kind() != reflect.Func || fnType.NumOut() == 0 {
+		throw(errors.New("injector: a factory function is expected"))
+	}
+
+	c.dependencies[name] = &dependency{
+		name:        name,
+		reflectType: fnType.Out(0),
+		factoryType: fnType,
+		prototype:   reflect.ValueOf(factoryFn),
+	}
+	c.names = append(c.names, name)
+	c.debug("injector: registered prototype", "name", name, "type", fnType.Out(0))
+	c.notifyRegistered(name)
+}
+
+// resolve validates that the dependency can be injected and creates a new instance if it's a prototype.
+func (c *Injector) resolve(name string, dep *dependency) (*dependency, error) {
+	if err := validateFulfilled(name, dep); err != nil {
+		return nil, err
+	}
+
+	if !dep.prototype.IsValid() {
+		return dep, nil
+	}
+
+	if dep.creating {
+		return nil, fmt.Errorf("injector: %s is a prototype depending on itself", name)
+	}
+
+	leave, err := c.enterResolution(name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer leave()
+
+	dep.creating = true
+	defer func() {
+		dep.creating = false
+	}()
+
+	created, err := c.executeFunc(dep.prototype.Interface(), dep.prototype.Type(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.prepare(name, created); err != nil {
+		return nil, err
+	}
+
+	created.name = name
+	return created, nil
+}
+
+func (c *Injector) mustResolve(dep *dependency) *dependency {
+	resolved, err := c.resolve(dep.name, dep)
+	if err != nil {
+		throw(err)
+	}
+
+	return resolved
+}
@@ -0,0 +1,81 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type counter struct {
+	Field int `injector:"mocked-int"`
+	Count int
+}
+
+func Test_NamedPrototypeFromFunc(t *testing.T) {
+	t.Run("new-instances", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		created := 0
+		c.NamedPrototypeFromFunc("counter", func() *counter {
+			created++
+			return &counter{}
+		})
+		require.Zero(t, created)
+
+		first := c.Get("counter").(*counter)
+		second := c.Get("counter").(*counter)
+		require.NotSame(t, first, second)
+		require.Equal(t, 10, first.Field)
+		require.Equal(t, 2, created)
+
+		target := &struct {
+			Named *counter `injector:"counter"`
+			Auto  *counter `injector:"auto"`
+		}{}
+		c.Inject(target)
+		require.NotSame(t, target.Named, target.Auto)
+		require.Equal(t, 4, created)
+
+		require.Len(t, ComponentsOfType[*counter](c), 1)
+		require.Equal(t, 5, created)
+	})
+
+	t.Run("factory-params", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		c.NamedPrototypeFromFunc("counter", func(v int) *counter {
+			return &counter{Count: v}
+		})
+		name := c.ComponentFromFunc(func(cnt *counter) int {
+			return cnt.Count + cnt.Field
+		})
+		require.Equal(t, 20, c.Get(name))
+	})
+
+	t.Run("missing-dependency", func(t *testing.T) {
+		c := New()
+		c.NamedPrototypeFromFunc("counter", func() *counter {
+			return &counter{}
+		})
+		require.PanicsWithError(t, "injector: mocked-int is not registered", func() {
+			c.Get("counter")
+		})
+	})
+
+	t.Run("depending-on-itself", func(t *testing.T) {
+		c := New()
+		c.NamedPrototypeFromFunc("counter", func(cnt *counter) *counter {
+			return &counter{}
+		})
+		require.PanicsWithError(t, "injector: counter is a prototype depending on itself", func() {
+			c.Get("counter")
+		})
+	})
+
+	t.Run("invalid-factory", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: a factory function is expected", func() {
+			c.NamedPrototypeFromFunc("counter", &counter{})
+		})
+	})
+}
@@ -0,0 +1,96 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Operation is a registration made to an Injector, e.g. NamedComponent, with its arguments.
+// Arguments are kept by reference, e.g. the registered component or the factory function.
+type Operation struct {
+	Method string
+	Args   []interface{}
+}
+
+// Recording returns registrations made to the Injector in order. Registrations made by other registrations,
+// e.g. NamedComponent by Component, aren't recorded separately, while registrations with generated names are
+// recorded with their names, e.g. Component is recorded as NamedComponent. Failed registrations aren't recorded.
+// Together with Replay, it allows reproducing the state of an Injector, e.g. to share a wiring issue.
+// Nothing is recorded with WithFastMode.
+func (c *Injector) Recording() []Operation {
+	return append([]Operation{}, c.operations...)
+}
+
+// Replay applies recorded registrations to the Injector, which should be created with the same options
+// as the recorded one. Factory functions are invoked again, so components created by factories
+// are new instances while registered components are shared.
+func (c *Injector) Replay(operations []Operation) {
+	for _, op := range operations {
+		c.replay(op)
+	}
+}
+
+func (c *Injector) replay(op Operation) {
+	args := op.Args
+	switch op.Method {
+	case "NamedComponent":
+		c.NamedComponent(args[0].(string), args[1])
+	case "RegisterValue":
+		c.RegisterValue(args[0].(string), args[1].(reflect.Value))
+	case "NamedComponentFromFuncWith":
+		c.NamedComponentFromFuncWith(args[0].(string), args[1], args[2:]...)
+	case "NamedComponentWith":
+		c.NamedComponentWith(args[0].(string), args[1], args[2:]...)
+	case "NamedComponentFromFactory":
+		c.NamedComponentFromFactory(args[0].(string), args[1].(Factory))
+	case "NamedPrototypeFromFunc":
+		c.NamedPrototypeFromFunc(args[0].(string), args[1])
+	case "KeyedComponent":
+		c.KeyedComponent(args[0], args[1])
+	case "ProvideInto":
+		c.ProvideInto(args[0].(string), args[1])
+	case "ProvideSlice":
+		c.ProvideSlice(args[0].(string), args[1:]...)
+	case "RegisterPlaceholder":
+		c.RegisterPlaceholder(args[0].(string), args[1])
+	case "Fulfill":
+		c.Fulfill(args[0].(string), args[1])
+	case "RegisterCombiner":
+		c.RegisterCombiner(args[0])
+	case "RegisterAdapter":
+		c.RegisterAdapter(args[0])
+	case "RegisterDefaults":
+		c.RegisterDefaults(args[0])
+	case "Freeze":
+		c.Freeze()
+	default:
+		throw(fmt.Errorf("injector: %s is an unknown operation", op.Method))
+	}
+}
+
+// recordOperation records a registration if it succeeds and isn't made by another registration.
+// It must be deferred with its result being called directly, e.g. defer c.recordOperation(method, args...)().
+func (c *Injector) recordOperation(method string, args ...interface{}) func() {
+	if c.fastMode {
+		return func() {
+			if r := recover(); r != nil {
+				panic(r)
+			}
+		}
+	}
+
+	c.operationDepth++
+	return func() {
+		c.operationDepth--
+		if r := recover(); r != nil {
+			panic(r)
+		}
+
+		if c.operationDepth == 0 {
+			c.operations = append(c.operations, Operation{
+				Method: method,
+				Args:   args,
+			})
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package injector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Recording(t *testing.T) {
+	c := New()
+	c.NamedComponent("mocked-int", 10)
+	typeAName := c.Component(&TypeA{})
+	c.ComponentFromFunc(func(a *TypeA) string {
+		return "created"
+	})
+	c.KeyedComponent(databasePrimary, "primary")
+	c.ProvideSlice("renderers", func() Renderer {
+		return mockRenderer("1")
+	})
+	c.RegisterPlaceholder("renderer", (*Renderer)(nil))
+	c.Fulfill("renderer", mockRenderer("fulfilled"))
+	_ = Run(func() {
+		c.NamedComponent("mocked-int", 11)
+	})
+	c.Freeze()
+
+	t.Run("recorded", func(t *testing.T) {
+		methods := []string{}
+		for _, op := range c.Recording() {
+			methods = append(methods, op.Method)
+		}
+
+		require.Equal(t, []string{
+			"NamedComponent",
+			"NamedComponent",
+			"NamedComponentFromFuncWith",
+			"KeyedComponent",
+			"ProvideSlice",
+			"RegisterPlaceholder",
+			"Fulfill",
+			"Freeze",
+		}, methods)
+		require.Equal(t, []interface{}{typeAName, c.Get(typeAName)}, c.Recording()[1].Args)
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		replayed := New()
+		replayed.Replay(c.Recording())
+		require.Equal(t, c.names, replayed.names)
+		require.Same(t, c.Get(typeAName), replayed.Get(typeAName))
+		require.Equal(t, "primary", replayed.GetByKey(databasePrimary))
+		require.Equal(t, c.Get("renderers"), replayed.Get("renderers"))
+		require.Equal(t, mockRenderer("fulfilled"), replayed.Get("renderer"))
+		require.True(t, replayed.frozen)
+		require.Len(t, replayed.Recording(), len(c.Recording()))
+	})
+
+	t.Run("with-options", func(t *testing.T) {
+		opts := []Option{WithBuildInfo(BuildInfo{Version: "v1.0.0"}), WithContext(context.Background())}
+		c := New(opts...)
+		c.NamedComponent("mocked-int", 10)
+		require.Len(t, c.Recording(), 1)
+
+		replayed := New(opts...)
+		replayed.Replay(c.Recording())
+		require.Equal(t, c.names, replayed.names)
+	})
+
+	t.Run("unknown-operation", func(t *testing.T) {
+		require.PanicsWithError(t, "injector: Unknown is an unknown operation", func() {
+			New().Replay([]Operation{{Method: "Unknown"}})
+		})
+	})
+}
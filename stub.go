@@ -0,0 +1,122 @@
+package injector
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// GenerateStub writes Go declarations of constants for the wiring of the Injector to w.
+// A constant holds the name of every registered component, with its type as a comment,
+// and a constant per dependency of a component refers to the constant of that dependency, e.g.
+//
+//	ComponentLogger            = "logger" // *main.LoggerImpl
+//	ComponentServer            = "server" // *main.Server
+//	ComponentServerNeedsLogger = ComponentLogger
+//
+// Dependencies provided by sources or the fallback, e.g. a host container of a plugin, get constants as well.
+// Referencing the constants instead of string literals, e.g. c.Get(wiring.ComponentLogger),
+// also lets the compiler catch components that are renamed or removed once the stub is regenerated.
+// The output has no package clause so a go:generate program can write one before it.
+// An error is returned if different names result in the same identifier.
+func (c *Injector) GenerateStub(w io.Writer) error {
+	types := map[string]string{}
+	dependsOn := map[string][]string{}
+	c.forEach(func(dep *dependency) {
+		types[dep.name] = dep.reflectType.String()
+		dependsOn[dep.name] = dep.dependsOn
+	})
+
+	for name := range c.collections {
+		types[name] = "collection"
+	}
+
+	for _, dependencies := range dependsOn {
+		for _, name := range dependencies {
+			if _, found := types[name]; found {
+				continue
+			}
+
+			if dep, found := c.lookup(name); found {
+				types[name] = dep.reflectType.String()
+			} else if c.collectionOwner(name) != nil {
+				types[name] = "collection"
+			}
+		}
+	}
+
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	identifiers := map[string]string{}
+	declare := func(identifier, source string) error {
+		if existing, found := identifiers[identifier]; found {
+			return fmt.Errorf("injector: %s and %s generate the same identifier %s", existing, source, identifier)
+		}
+
+		identifiers[identifier] = source
+		return nil
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("// Code generated by injector.GenerateStub. DO NOT EDIT.\n\nconst (\n")
+	for _, name := range names {
+		identifier := "Component" + toIdentifier(name)
+		if err := declare(identifier, name); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(buf, "%s = %q // %s\n", identifier, name, types[name])
+	}
+
+	for _, name := range names {
+		identifier := "Component" + toIdentifier(name)
+		declared := map[string]bool{}
+		for _, dependency := range dependsOn[name] {
+			if declared[dependency] {
+				continue
+			}
+
+			declared[dependency] = true
+			dependencyIdentifier := identifier + "Needs" + toIdentifier(dependency)
+			if err := declare(dependencyIdentifier, name+" -> "+dependency); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(buf, "%s = Component%s\n", dependencyIdentifier, toIdentifier(dependency))
+		}
+	}
+	buf.WriteString(")\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(src)
+	return err
+}
+
+// toIdentifier converts a component name to an exported Go identifier,
+// e.g. "mocked-int" becomes "MockedInt".
+func toIdentifier(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	b := strings.Builder{}
+	for _, part := range parts {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package injector
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// typeCheckStub type-checks the generated stub as the content of a package.
+func typeCheckStub(t *testing.T, stub string) error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "wiring.go", "package wiring\n\n"+stub, 0)
+	require.NoError(t, err)
+
+	_, err = (&types.Config{}).Check("wiring", fset, []*ast.File{file}, nil)
+	return err
+}
+
+func Test_GenerateStub(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("type-a", &TypeA{})
+		buf := &bytes.Buffer{}
+		require.NoError(t, c.GenerateStub(buf))
+		require.Equal(t, `// Code generated by injector.GenerateStub. DO NOT EDIT.
+
+const (
+	ComponentMockedInt           = "mocked-int" // int
+	ComponentTypeA               = "type-a"     // *injector.TypeA
+	ComponentTypeANeedsMockedInt = ComponentMockedInt
+)
+`, buf.String())
+		require.NoError(t, typeCheckStub(t, buf.String()))
+	})
+
+	t.Run("fallback-dependency", func(t *testing.T) {
+		host := New()
+		host.NamedComponent("mocked-int", 10)
+		host.ProvideInto("numbers", func() int {
+			return 1
+		})
+		c := New()
+		c.SetFallback(host)
+		c.NamedComponent("type-a", &TypeA{})
+		c.NamedComponent("with-numbers", &struct {
+			Numbers []int `injector:"numbers"`
+		}{})
+		buf := &bytes.Buffer{}
+		require.NoError(t, c.GenerateStub(buf))
+		require.Regexp(t, `ComponentMockedInt\s+= "mocked-int"\s+// int`, buf.String())
+		require.Regexp(t, `ComponentNumbers\s+= "numbers"\s+// collection`, buf.String())
+		require.NoError(t, typeCheckStub(t, buf.String()))
+	})
+
+	t.Run("duplicate-identifier", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("mocked.int", 11)
+		err := c.GenerateStub(&bytes.Buffer{})
+		require.EqualError(t, err, "injector: mocked-int and mocked.int generate the same identifier ComponentMockedInt")
+	})
+
+	t.Run("duplicate-dependency-identifier", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("type-a", &TypeA{})
+		c.NamedComponent("type-a-needs-mocked-int", 11)
+		err := c.GenerateStub(&bytes.Buffer{})
+		require.EqualError(t, err, "injector: type-a-needs-mocked-int and type-a -> mocked-int generate the same identifier ComponentTypeANeedsMockedInt")
+	})
+}
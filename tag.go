@@ -0,0 +1,90 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+const (
+	configInjectionTag = "config"
+	compositeOption    = "composite"
+	createOption       = "create"
+	ifEmptyOption      = "ifempty"
+	prefixOption       = "prefix"
+	requiredOption     = "required"
+	fallbackSeparator  = "|"
+)
+
+// placeholderPattern matches placeholders in names of tags, e.g. {env} in db.{env}.url.
+var placeholderPattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// injectTag is a parsed tag in the form of `injector:"name,option,key=value"`.
+type injectTag struct {
+	name    string
+	options map[string]string
+}
+
+func parseTag(tag string) injectTag {
+	parts := strings.Split(tag, ",")
+	parsed := injectTag{
+		name:    strings.TrimSpace(parts[0]),
+		options: map[string]string{},
+	}
+
+	for _, part := range parts[1:] {
+		key, value, _ := strings.Cut(part, "=")
+		parsed.options[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return parsed
+}
+
+func (t injectTag) has(option string) bool {
+	_, ok := t.options[option]
+	return ok
+}
+
+// alternatives splits a tag in the form of `injector:"logger|auto"` into tags which are tried in order.
+// Options are shared by all alternatives.
+func (t injectTag) alternatives() []injectTag {
+	names := strings.Split(t.name, fallbackSeparator)
+	alternatives := make([]injectTag, 0, len(names))
+	for _, name := range names {
+		alternatives = append(alternatives, injectTag{
+			name:    strings.TrimSpace(name),
+			options: t.options,
+		})
+	}
+
+	return alternatives
+}
+
+// interpolate substitutes placeholders in name by values of the string components named in the placeholders.
+func (c *Injector) interpolate(name string) (string, error) {
+	var err error
+	interpolated := placeholderPattern.ReplaceAllStringFunc(name, func(placeholder string) string {
+		sourceName := strings.TrimSpace(placeholder[1 : len(placeholder)-1])
+		source, found := c.lookup(sourceName)
+		if !found {
+			err = fmt.Errorf("injector: %s used in %s is not registered", sourceName, name)
+			return placeholder
+		}
+
+		source, resolveErr := c.resolve(sourceName, source)
+		if resolveErr != nil {
+			err = resolveErr
+			return placeholder
+		}
+
+		if source.reflectType.Kind() != reflect.String {
+			err = fmt.Errorf("injector: %s used in %s is %s, a string is expected", sourceName, name, source.reflectType)
+			return placeholder
+		}
+
+		return source.reflectValue.String()
+	})
+
+	return interpolated, err
+}
@@ -0,0 +1,34 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseTag(t *testing.T) {
+	t.Run("name-only", func(t *testing.T) {
+		tag := parseTag("logger")
+		require.Equal(t, "logger", tag.name)
+		require.Empty(t, tag.options)
+	})
+
+	t.Run("with-options", func(t *testing.T) {
+		tag := parseTag("auto, composite,key=value")
+		require.Equal(t, "auto", tag.name)
+		require.True(t, tag.has("composite"))
+		require.Equal(t, "value", tag.options["key"])
+		require.False(t, tag.has("missing"))
+	})
+}
+
+func Test_injectTag_alternatives(t *testing.T) {
+	tag := parseTag("logger | auto,ifempty")
+	alternatives := tag.alternatives()
+	require.Len(t, alternatives, 2)
+	require.Equal(t, "logger", alternatives[0].name)
+	require.Equal(t, "auto", alternatives[1].name)
+	require.True(t, alternatives[1].has("ifempty"))
+
+	require.Len(t, parseTag("logger").alternatives(), 1)
+}
@@ -0,0 +1,42 @@
+package injector
+
+import (
+	"time"
+)
+
+// WithTimings enables recording how long each component takes to be created by its factory,
+// including injecting its dependencies. Recorded durations are available via Timings.
+// Nothing is recorded by default.
+func WithTimings() Option {
+	return func(c *Injector) {
+		c.timings = map[string]time.Duration{}
+	}
+}
+
+// Timings returns how long each component took to be created, keyed by component names.
+// Elements of a collection are keyed by "<collection name>[<index>]".
+// It's empty unless WithTimings is used.
+func (c *Injector) Timings() map[string]time.Duration {
+	timings := make(map[string]time.Duration, len(c.timings))
+	for name, d := range c.timings {
+		timings[name] = d
+	}
+
+	return timings
+}
+
+func (c *Injector) startTiming() time.Time {
+	if c.timings == nil {
+		return time.Time{}
+	}
+
+	return time.Now()
+}
+
+func (c *Injector) recordTiming(name string, start time.Time) {
+	if c.timings == nil {
+		return
+	}
+
+	c.timings[name] = time.Since(start)
+}
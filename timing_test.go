@@ -0,0 +1,40 @@
+package injector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Timings(t *testing.T) {
+	t.Run("enabled", func(t *testing.T) {
+		c := New(WithTimings())
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponentFromFunc("slow", func() string {
+			time.Sleep(10 * time.Millisecond)
+			return "slow"
+		})
+		name := c.ComponentFromFactory(&mockFactory{mockResult: "created"})
+		c.NamedComponentFromFactory("named-factory", &mockFactory{mockResult: "created"})
+		c.ProvideInto("renderers", func() *rendererImpl {
+			return &rendererImpl{}
+		})
+		c.Get("renderers")
+
+		timings := c.Timings()
+		require.Len(t, timings, 4)
+		require.True(t, timings["slow"] >= 10*time.Millisecond)
+		require.Contains(t, timings, name)
+		require.Contains(t, timings, "named-factory")
+		require.Contains(t, timings, "renderers[0]")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		c := New()
+		c.NamedComponentFromFunc("fast", func() string {
+			return "fast"
+		})
+		require.Empty(t, c.Timings())
+	})
+}
@@ -0,0 +1,124 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// GetOr loads a component from the Injector using name, the same way as Get, and returns it as T.
+// Unlike Get, fallback is returned instead of panicking if the component couldn't be loaded,
+// e.g. it isn't registered or creating it fails, or if it isn't of type T.
+// Panics which aren't raised by the Injector, e.g. from factory functions, still propagate.
+func GetOr[T any](c *Injector, name string, fallback T) T {
+	var component interface{}
+	if err := Run(func() {
+		component = c.Get(name)
+	}); err != nil {
+		return fallback
+	}
+
+	v, ok := component.(T)
+	if !ok {
+		return fallback
+	}
+
+	return v
+}
+
+// ComponentsOfType returns all components matching T in registration order. Types are matched by the matcher
+// set via WithMatcher, assignability by default.
+// Unlike injecting by types, having more than one eligible component isn't a conflict.
+// It's useful to iterate all implementations of an interface.
+func ComponentsOfType[T any](c *Injector) []T {
+	defer annotatePanic("ComponentsOfType", "")
+
+	t := typeOf[T]()
+	components := []T{}
+	c.forEach(func(dep *dependency) {
+		if dep.placeholder || !c.matcher(dep.reflectType, t) {
+			return
+		}
+
+		v, err := convertValue(c.mustResolve(dep).reflectValue, t)
+		if err != nil {
+			throw(err)
+		}
+
+		var component T
+		reflect.ValueOf(&component).Elem().Set(v)
+		components = append(components, component)
+	})
+
+	return components
+}
+
+// MustGetTyped loads a component from the Injector using name and returns it as T.
+// Similar to Get, it panics if the component couldn't be found. It also panics if the component isn't of type T.
+func MustGetTyped[T any](c *Injector, name string) T {
+	defer annotatePanic("MustGetTyped", name)
+
+	component := c.Get(name)
+	v, ok := component.(T)
+	if !ok {
+		throw(fmt.Errorf("injector: %s is %s, want %s", name, reflect.TypeOf(component), typeOf[T]()))
+	}
+
+	return v
+}
+
+// Singleton registers the component created by ctor under type T with a generated name, which is returned.
+// ctor is invoked once with the Injector to resolve its dependencies manually, e.g. via GetByType,
+// so no reflection on its signature is involved. It panics with the error returned by ctor.
+// The component is registered as T, so it can be loaded via GetByType[T] even if T is an interface.
+func Singleton[T any](c *Injector, ctor func(*Injector) (T, error)) string {
+	defer annotatePanic("Singleton", "")
+
+	c.validateFrozen()
+
+	v, err := ctor(c)
+	if err != nil {
+		throw(err)
+	}
+
+	return Value(c, v)
+}
+
+// Value registers v under type T with a generated name, which is returned.
+// Unlike Component, T is used instead of the dynamic type of v, e.g. an interface.
+func Value[T any](c *Injector, v T) string {
+	name := c.nextGeneratedName(typeOf[T]())
+	defer annotatePanic("Value", name)
+
+	c.RegisterValue(name, reflect.ValueOf(&v).Elem())
+	return name
+}
+
+// GetByType loads the component of type T from the Injector the same way as injecting a field via `injector:"auto"`.
+// It panics if no component or more than one component is eligible.
+func GetByType[T any](c *Injector) T {
+	defer annotatePanic("GetByType", "")
+
+	t := typeOf[T]()
+	name, dep, err := c.findByType(t)
+	if err != nil {
+		throw(err)
+	}
+
+	dep, err = c.resolve(name, dep)
+	if err != nil {
+		throw(err)
+	}
+
+	v, err := convertValue(dep.reflectValue, t)
+	if err != nil {
+		throw(err)
+	}
+
+	var component T
+	reflect.ValueOf(&component).Elem().Set(v)
+	return component
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,273 @@
+package injector
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetOr(t *testing.T) {
+	t.Run("present", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		require.Equal(t, 10, GetOr(c, "mocked-int", 1))
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		c := New()
+		require.Equal(t, 1, GetOr(c, "mocked-int", 1))
+	})
+
+	t.Run("wrong-type", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", "10")
+		require.Equal(t, 1, GetOr(c, "mocked-int", 1))
+	})
+
+	t.Run("failed-prototype", func(t *testing.T) {
+		c := New()
+		c.NamedPrototypeFromFunc("mocked-int", func(s string) int {
+			return len(s)
+		})
+		require.Equal(t, 1, GetOr(c, "mocked-int", 1))
+	})
+
+	t.Run("collection", func(t *testing.T) {
+		c := New()
+		c.ProvideInto("numbers", func() int {
+			return 10
+		})
+		require.Equal(t, []interface{}{10}, GetOr[[]interface{}](c, "numbers", nil))
+
+		c.ProvideInto("failed", func(s string) int {
+			return len(s)
+		})
+		require.Nil(t, GetOr[[]interface{}](c, "failed", nil))
+	})
+}
+
+type user struct{}
+
+type order struct{}
+
+type repository[T any] struct {
+	items []T
+}
+
+func (r *repository[T]) Items() []T {
+	return r.items
+}
+
+type store[T any] interface {
+	Items() []T
+}
+
+type userStore interface {
+	Items() []user
+}
+
+func Test_findByType_generic_instances(t *testing.T) {
+	t.Run("distinct-instances", func(t *testing.T) {
+		c := New()
+		users := &repository[user]{}
+		orders := &repository[order]{}
+		c.Component(users)
+		c.Component(orders)
+		target := &struct {
+			Users  *repository[user]  `injector:"auto"`
+			Orders *repository[order] `injector:"auto"`
+		}{}
+		c.Inject(target)
+		require.Same(t, users, target.Users)
+		require.Same(t, orders, target.Orders)
+	})
+
+	t.Run("factory-params", func(t *testing.T) {
+		c := New()
+		c.Component(&repository[user]{items: []user{{}}})
+		c.Component(&repository[order]{})
+		name := c.ComponentFromFunc(func(r *repository[user]) []user {
+			return r.items
+		})
+		require.Len(t, c.Get(name), 1)
+	})
+
+	t.Run("missing-instance", func(t *testing.T) {
+		c := New()
+		c.Component(&repository[user]{})
+		require.PanicsWithError(t, "injector: couldn't find the dependency for *injector.repository[github.com/bongnv/injector.order]", func() {
+			c.Inject(&struct {
+				Orders *repository[order] `injector:"auto"`
+			}{})
+		})
+	})
+}
+
+func Test_findByType_generic_interfaces(t *testing.T) {
+	c := New()
+	users := &repository[user]{items: []user{{}}}
+	c.Component(users)
+	c.Component(&repository[order]{})
+
+	t.Run("instances", func(t *testing.T) {
+		target := &struct {
+			Users  store[user]  `injector:"auto"`
+			Orders store[order] `injector:"auto"`
+		}{}
+		c.Inject(target)
+		require.Same(t, users, target.Users)
+		require.Len(t, target.Orders.Items(), 0)
+	})
+
+	t.Run("method-sets", func(t *testing.T) {
+		target := &struct {
+			Users userStore `injector:"auto"`
+		}{}
+		c.Inject(target)
+		require.Same(t, users, target.Users)
+	})
+
+	t.Run("missing-instance", func(t *testing.T) {
+		require.PanicsWithError(t, "injector: couldn't find the dependency for injector.store[string]", func() {
+			c.Inject(&struct {
+				Strings store[string] `injector:"auto"`
+			}{})
+		})
+	})
+
+	t.Run("conflict-by-element-type", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("users-a", &repository[user]{})
+		c.NamedComponent("users-b", &repository[user]{})
+		c.NamedComponent("orders", &repository[order]{})
+		require.True(t, c.IsAmbiguous(typeOf[store[user]]()))
+		require.False(t, c.IsAmbiguous(typeOf[store[order]]()))
+		require.PanicsWithError(t, "injector: there is a conflict when finding the dependency for injector.store[github.com/bongnv/injector.user]: [users-a (*injector.repository[github.com/bongnv/injector.user]), users-b (*injector.repository[github.com/bongnv/injector.user])]", func() {
+			GetByType[store[user]](c)
+		})
+	})
+
+	t.Run("other-instantiation-only", func(t *testing.T) {
+		c := New()
+		c.Component(&repository[any]{})
+		require.PanicsWithError(t, "injector: couldn't find the dependency for injector.store[github.com/bongnv/injector.user]", func() {
+			GetByType[store[user]](c)
+		})
+		require.NotNil(t, GetByType[store[any]](c))
+	})
+}
+
+func Test_ComponentsOfType(t *testing.T) {
+	t.Run("several-implementers", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("renderer-b", mockRenderer("b"))
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("renderer-a", mockRenderer("a"))
+		c.NamedComponent("renderer-c", &rendererImpl{})
+		c.RegisterPlaceholder("renderer-d", (*Renderer)(nil))
+
+		renderers := ComponentsOfType[Renderer](c)
+		require.Len(t, renderers, 3)
+		require.Equal(t, "b", renderers[0].Render())
+		require.Equal(t, "a", renderers[1].Render())
+		require.Equal(t, "rendered", renderers[2].Render())
+	})
+
+	t.Run("concrete-type", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		c.NamedComponent("another-int", 11)
+		require.Equal(t, []int{10, 11}, ComponentsOfType[int](c))
+	})
+
+	t.Run("no-match", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("mocked-int", 10)
+		require.Empty(t, ComponentsOfType[Renderer](c))
+	})
+}
+
+func Test_MustGetTyped(t *testing.T) {
+	t.Run("happy-path", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("renderer", &rendererImpl{})
+		require.Equal(t, "rendered", MustGetTyped[Renderer](c, "renderer").Render())
+	})
+
+	t.Run("wrong-type", func(t *testing.T) {
+		c := New()
+		c.NamedComponent("renderer", "not-renderer")
+		require.PanicsWithError(t, "injector: renderer is string, want injector.Renderer", func() {
+			MustGetTyped[Renderer](c, "renderer")
+		})
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: the requested dependency couldn't be found", func() {
+			MustGetTyped[Renderer](c, "renderer")
+		})
+	})
+}
+
+func Test_Singleton(t *testing.T) {
+	t.Run("resolution", func(t *testing.T) {
+		c := New()
+		Value(c, 10)
+		name := Singleton(c, func(c *Injector) (Renderer, error) {
+			return mockRenderer(fmt.Sprint(GetByType[int](c))), nil
+		})
+
+		require.Equal(t, "10", GetByType[Renderer](c).Render())
+		require.Equal(t, "10", MustGetTyped[Renderer](c, name).Render())
+	})
+
+	t.Run("ctor-error", func(t *testing.T) {
+		c := New()
+		errCtor := errors.New("ctor error")
+		err := Run(func() {
+			Singleton(c, func(c *Injector) (*rendererImpl, error) {
+				return nil, errCtor
+			})
+		})
+
+		require.True(t, errors.Is(err, errCtor))
+		require.Empty(t, ComponentsOfType[*rendererImpl](c))
+	})
+
+	t.Run("ctor-resolution-error", func(t *testing.T) {
+		c := New()
+		require.PanicsWithError(t, "injector: couldn't find the dependency for int", func() {
+			Singleton(c, func(c *Injector) (string, error) {
+				return fmt.Sprint(GetByType[int](c)), nil
+			})
+		})
+	})
+}
+
+func Test_Value(t *testing.T) {
+	t.Run("registered-as-t", func(t *testing.T) {
+		c := New()
+		Value[Renderer](c, &rendererImpl{})
+		Value(c, &rendererImpl{})
+		require.False(t, c.IsAmbiguous(reflectTypeOfRenderer))
+		require.Equal(t, "rendered", GetByType[Renderer](c).Render())
+	})
+
+	t.Run("nil-interface", func(t *testing.T) {
+		c := New()
+		Value[Renderer](c, nil)
+		require.Nil(t, GetByType[Renderer](c))
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		c := New()
+		Value(c, 1)
+		Value(c, 2)
+		require.Panics(t, func() {
+			GetByType[int](c)
+		})
+	})
+}
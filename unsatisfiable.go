@@ -0,0 +1,153 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DetectUnsatisfiable reports dependencies of prototypes and of collections which aren't assembled yet
+// that can't be satisfied by the current registrations. As their factory functions are only invoked
+// when they are requested, a missing or ambiguous dependency would otherwise surface on first use.
+// Parameters of factory functions and tagged fields of the components they create are checked
+// the same way as they are injected, but no factory function or adapter is invoked.
+// Tags resolved at injection time, e.g. configs, groups, composites and tags with placeholders, aren't checked.
+// It returns nil if every dependency can be satisfied.
+func (c *Injector) DetectUnsatisfiable() []error {
+	var errs []error
+	c.forEach(func(dep *dependency) {
+		if dep.prototype.IsValid() {
+			errs = append(errs, c.detectUnsatisfiableFunc(dep.name, dep.factoryType)...)
+		}
+	})
+
+	names := make([]string, 0, len(c.collections))
+	for name := range c.collections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		col := c.collections[name]
+		if col.assembled {
+			continue
+		}
+
+		for i, factoryFn := range col.factories {
+			errs = append(errs, c.detectUnsatisfiableFunc(fmt.Sprintf("%s[%d]", name, i), reflect.TypeOf(factoryFn))...)
+		}
+	}
+
+	return errs
+}
+
+func (c *Injector) detectUnsatisfiableFunc(name string, fnType reflect.Type) []error {
+	var errs []error
+	for i := 0; i < fnType.NumIn(); i++ {
+		if err := c.checkByType(fnType.In(i)); err != nil {
+			errs = append(errs, fmt.Errorf("injector: %s: %w", name, err))
+		}
+	}
+
+	if fnType.NumOut() == 0 || !isStructPtr(fnType.Out(0)) {
+		return errs
+	}
+
+	structType := fnType.Out(0).Elem()
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		tagValue, ok := lookupTag(structField.Tag, c.tagKeys)
+		if !ok {
+			continue
+		}
+
+		if err := c.checkField(structField, parseTag(tagValue)); err != nil {
+			errs = append(errs, fmt.Errorf("injector: %s: %w", name, err))
+		}
+	}
+
+	return errs
+}
+
+// checkField returns the error injecting the field would result in, if it's known without injecting it.
+func (c *Injector) checkField(structField reflect.StructField, tag injectTag) error {
+	if tag.has(ifEmptyOption) || tag.has(compositeOption) || strings.Contains(tag.name, "{") {
+		return nil
+	}
+
+	if _, hasDefault := c.lookupDefault(structField.Name); hasDefault || c.isOptionalMissing(tag) {
+		return nil
+	}
+
+	var err error
+	for _, alternative := range tag.alternatives() {
+		if err = c.checkTag(alternative, structField.Type); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+func (c *Injector) checkTag(tag injectTag, t reflect.Type) error {
+	switch {
+	case tag.name == selfInjectionTag, tag.name == configInjectionTag && tag.has(prefixOption),
+		isGroupTag(tag), isGroupNamesTag(tag):
+		return nil
+	case tag.name == autoInjectionTag:
+		err := c.checkByType(t)
+		if err != nil && isPtrToPtr(t) {
+			err = c.checkByType(t.Elem())
+		}
+
+		if err != nil && tag.has(createOption) && isNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if c.collectionOwner(tag.name) != nil {
+		return nil
+	}
+
+	dep, found := c.lookup(tag.name)
+	if !found {
+		return errNotFound("injector: %s is not registered", tag.name)
+	}
+
+	return validateFulfilled(tag.name, dep)
+}
+
+// checkByType returns the error findByType would return for t without adapting or creating any component.
+func (c *Injector) checkByType(t reflect.Type) error {
+	if c.noAutoInjection {
+		return errNoAutoInjection(t)
+	}
+
+	candidates := c.candidatesOf(t)
+	if len(candidates) > 1 {
+		return errConflict(t, candidates)
+	}
+
+	if len(candidates) == 1 {
+		return validateFulfilled(candidates[0].name, candidates[0])
+	}
+
+	if _, adapted, conflicted := c.findAdapter(t); conflicted {
+		return errAdapterConflict(t)
+	} else if adapted != nil {
+		return nil
+	}
+
+	if c.fallback != nil {
+		return c.fallback.checkByType(t)
+	}
+
+	if isPtrToInterface(t) {
+		return errPointerToInterface(t)
+	}
+
+	return errNotFound("injector: couldn't find the dependency for %s", t.String())
+}
@@ -0,0 +1,76 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DetectUnsatisfiable(t *testing.T) {
+	t.Run("satisfiable", func(t *testing.T) {
+		c := New()
+		c.NamedPrototypeFromFunc("prototype", func(v int) *TypeA {
+			return &TypeA{}
+		})
+		c.ProvideInto("renderers", func(a *TypeA) Renderer {
+			return mockRenderer("1")
+		})
+		c.NamedComponent("mocked-int", 10)
+		require.Empty(t, c.DetectUnsatisfiable())
+	})
+
+	t.Run("unsatisfiable", func(t *testing.T) {
+		invoked := false
+		c := New()
+		c.NamedComponent("renderer-1", mockRenderer("1"))
+		c.NamedComponent("renderer-2", &rendererImpl{})
+		c.NamedPrototypeFromFunc("prototype", func(v int, r Renderer) *TypeB {
+			invoked = true
+			return &TypeB{}
+		})
+		c.ProvideInto("numbers", func(s string) int {
+			invoked = true
+			return 1
+		})
+
+		errs := c.DetectUnsatisfiable()
+		require.Len(t, errs, 4)
+		require.EqualError(t, errs[0], "injector: prototype: injector: couldn't find the dependency for int")
+		require.EqualError(t, errs[1], "injector: prototype: injector: there is a conflict when finding the dependency for injector.Renderer: [renderer-1 (injector.mockRenderer), renderer-2 (*injector.rendererImpl)]")
+		require.EqualError(t, errs[2], "injector: prototype: injector: type-a is not registered")
+		require.EqualError(t, errs[3], "injector: numbers[0]: injector: couldn't find the dependency for string")
+		require.True(t, isNotFound(errs[0]))
+		require.False(t, invoked)
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		c := New(WithOptionalByDefault())
+		c.RegisterPlaceholder("renderer", (*Renderer)(nil))
+		c.NamedPrototypeFromFunc("prototype", func() *struct {
+			Renderer Renderer `injector:"renderer"`
+			Optional int      `injector:"missing"`
+			Fallback int      `injector:"missing|auto"`
+			Self     *TypeA   `injector:"self"`
+		} {
+			return nil
+		})
+
+		errs := c.DetectUnsatisfiable()
+		require.Len(t, errs, 2)
+		require.EqualError(t, errs[0], "injector: prototype: injector: renderer is a placeholder which isn't fulfilled yet")
+		require.EqualError(t, errs[1], "injector: prototype: injector: couldn't find the dependency for int")
+	})
+
+	t.Run("fallback-and-adapters", func(t *testing.T) {
+		host := New()
+		host.NamedComponent("mocked-int", 10)
+		c := New()
+		c.SetFallback(host)
+		c.RegisterAdapter(adaptLegacyPrinter)
+		c.NamedComponent("printer", &legacyPrinter{text: "adapted"})
+		c.NamedPrototypeFromFunc("prototype", func(v int, r Renderer) *TypeD {
+			return &TypeD{}
+		})
+		require.Empty(t, c.DetectUnsatisfiable())
+	})
+}
@@ -1,32 +1,211 @@
 package injector
 
 import (
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
 	"reflect"
+	"strings"
+	"time"
 )
 
 var (
-	reflectTypeOfError = reflect.TypeOf((*error)(nil)).Elem()
+	reflectTypeOfError           = reflect.TypeOf((*error)(nil)).Elem()
+	reflectTypeOfInterfaces      = reflect.TypeOf([]interface{}{})
+	reflectTypeOfTextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	reflectTypeOfDuration        = reflect.TypeOf(time.Duration(0))
+
+	errUntypedNil = errors.New("injector: an untyped nil can't be registered without a name, a typed value is expected")
 )
 
 func isStructPtr(t reflect.Type) bool {
 	return t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct
 }
 
+func isPtrToPtr(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Ptr
+}
+
+func isEmptyInterface(t reflect.Type) bool {
+	return t.Kind() == reflect.Interface && t.NumMethod() == 0
+}
+
+func isPtrToInterface(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Interface
+}
+
+func errPointerToInterface(t reflect.Type) error {
+	return fmt.Errorf("injector: %s is a pointer to an interface, use %s as the field type instead", t, t.Elem())
+}
+
+func errConflict(t reflect.Type, candidates []*dependency) error {
+	if isEmptyInterface(t) {
+		return fmt.Errorf("injector: there is a conflict when finding the dependency for %s, any component is assignable to it, please inject it by name", t.String())
+	}
+
+	described := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		described = append(described, fmt.Sprintf("%s (%s)", candidate.name, candidate.reflectType))
+	}
+
+	return fmt.Errorf("injector: there is a conflict when finding the dependency for %s: [%s]", t.String(), strings.Join(described, ", "))
+}
+
+func errNoAutoInjection(t reflect.Type) error {
+	return fmt.Errorf("injector: injecting %s by type is disabled by WithNoAutoInjection, please inject it by name", t)
+}
+
 func implementsError(t reflect.Type) bool {
 	return t.Implements(reflectTypeOfError)
 }
 
-func hasInjectTag(dep *dependency) bool {
+func hasInjectTag(dep *dependency, keys []string) bool {
 	if dep.reflectType.Kind() != reflect.Struct {
 		return false
 	}
 
 	for i := 0; i < dep.reflectType.NumField(); i++ {
 		structField := dep.reflectType.Field(i)
-		if _, ok := structField.Tag.Lookup("injector"); ok {
+		if _, ok := lookupTag(structField.Tag, keys); ok {
 			return true
 		}
 	}
 
 	return false
 }
+
+// notFoundError is returned when a requested dependency isn't registered.
+type notFoundError struct {
+	msg string
+}
+
+func (e *notFoundError) Error() string {
+	return e.msg
+}
+
+func errNotFound(format string, args ...interface{}) error {
+	return &notFoundError{msg: fmt.Sprintf(format, args...)}
+}
+
+func isNotFound(err error) bool {
+	var notFound *notFoundError
+	return errors.As(err, &notFound)
+}
+
+// convertValue converts v to type t if v isn't assignable to t.
+func convertValue(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	if v.Type().AssignableTo(t) {
+		return v, nil
+	}
+
+	if v.Type().ConvertibleTo(t) {
+		return v.Convert(t), nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("injector: %s is matched with %s but it isn't convertible", v.Type(), t)
+}
+
+// argumentOf returns the value of arg as a parameter of type t.
+func argumentOf(arg interface{}, t reflect.Type) (reflect.Value, error) {
+	if arg == nil {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			return reflect.Zero(t), nil
+		default:
+			return reflect.Value{}, fmt.Errorf("nil is not assignable to %s", t)
+		}
+	}
+
+	v := reflect.ValueOf(arg)
+	if v.Type().AssignableTo(t) {
+		return v, nil
+	}
+
+	if isNumber(v.Kind()) && isNumber(t.Kind()) {
+		if converted, ok := convertNumber(v, t); ok {
+			return converted, nil
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("%s is not assignable to %s", v.Type(), t)
+}
+
+func isNumber(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
+
+// convertNumber converts the number v to type t only if the conversion is exact,
+// i.e. the value neither overflows t nor loses its fraction or precision.
+func convertNumber(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	zero := reflect.Zero(t)
+	switch {
+	case v.CanInt() && zero.CanInt():
+		if zero.OverflowInt(v.Int()) {
+			return reflect.Value{}, false
+		}
+	case v.CanUint() && zero.CanInt():
+		if v.Uint() > math.MaxInt64 || zero.OverflowInt(int64(v.Uint())) {
+			return reflect.Value{}, false
+		}
+	case v.CanInt() && zero.CanUint():
+		if v.Int() < 0 || zero.OverflowUint(uint64(v.Int())) {
+			return reflect.Value{}, false
+		}
+	case v.CanUint() && zero.CanUint():
+		if zero.OverflowUint(v.Uint()) {
+			return reflect.Value{}, false
+		}
+	case v.CanFloat() && zero.CanInt():
+		f := v.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 || zero.OverflowInt(int64(f)) {
+			return reflect.Value{}, false
+		}
+	case v.CanFloat() && zero.CanUint():
+		f := v.Float()
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 || zero.OverflowUint(uint64(f)) {
+			return reflect.Value{}, false
+		}
+	case v.CanFloat() && zero.CanFloat():
+		if zero.OverflowFloat(v.Float()) {
+			return reflect.Value{}, false
+		}
+	}
+
+	// converting back detects a loss of precision, e.g. a large int64 to float64 or 1.1 to float32
+	converted := v.Convert(t)
+	if !converted.Convert(v.Type()).Equal(v) {
+		return reflect.Value{}, false
+	}
+
+	return converted, true
+}
+
+// lookupTag returns the value of the first key found in the tag.
+func lookupTag(tag reflect.StructTag, keys []string) (string, bool) {
+	for _, key := range keys {
+		if value, ok := tag.Lookup(key); ok {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+func findOverride(overrides []interface{}, t reflect.Type) (reflect.Value, bool, error) {
+	var found reflect.Value
+	for _, override := range overrides {
+		v := reflect.ValueOf(override)
+		if !v.IsValid() || !v.Type().AssignableTo(t) {
+			continue
+		}
+
+		if found.IsValid() {
+			return reflect.Value{}, false, fmt.Errorf("injector: there is a conflict when finding the override for %s", t)
+		}
+
+		found = v
+	}
+
+	return found, found.IsValid(), nil
+}
@@ -1,6 +1,7 @@
 package injector
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
@@ -14,5 +15,53 @@ func Test_hasInjectTag_no_tags(t *testing.T) {
 		value:        v,
 		reflectValue: reflect.ValueOf(v),
 		reflectType:  reflect.TypeOf(v),
-	}))
+	}, []string{defaultTagKey}))
+}
+
+func Test_findOverride_nil(t *testing.T) {
+	v, found, err := findOverride([]interface{}{nil}, reflect.TypeOf(""))
+	require.NoError(t, err)
+	require.False(t, found)
+	require.False(t, v.IsValid())
+}
+
+func Test_argumentOf_numbers(t *testing.T) {
+	t.Run("exact", func(t *testing.T) {
+		for _, tc := range []struct {
+			arg      interface{}
+			expected interface{}
+		}{
+			{arg: 5, expected: int8(5)},
+			{arg: 5, expected: uint(5)},
+			{arg: 2.0, expected: 2},
+			{arg: int64(-3), expected: -3.0},
+			{arg: uint8(255), expected: int16(255)},
+			{arg: 0.5, expected: float32(0.5)},
+		} {
+			v, err := argumentOf(tc.arg, reflect.TypeOf(tc.expected))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, v.Interface())
+		}
+	})
+
+	t.Run("lossy", func(t *testing.T) {
+		for _, tc := range []struct {
+			arg      interface{}
+			t        reflect.Type
+			expected string
+		}{
+			{arg: 1.9, t: reflect.TypeOf(0), expected: "float64 is not assignable to int"},
+			{arg: -1, t: reflect.TypeOf(uint(0)), expected: "int is not assignable to uint"},
+			{arg: 300, t: reflect.TypeOf(int8(0)), expected: "int is not assignable to int8"},
+			{arg: uint64(math.MaxUint64), t: reflect.TypeOf(int64(0)), expected: "uint64 is not assignable to int64"},
+			{arg: 1e20, t: reflect.TypeOf(int64(0)), expected: "float64 is not assignable to int64"},
+			{arg: -2.0, t: reflect.TypeOf(uint(0)), expected: "float64 is not assignable to uint"},
+			{arg: 1e300, t: reflect.TypeOf(float32(0)), expected: "float64 is not assignable to float32"},
+			{arg: 1.1, t: reflect.TypeOf(float32(0)), expected: "float64 is not assignable to float32"},
+			{arg: int64(1<<53 + 1), t: reflect.TypeOf(0.0), expected: "int64 is not assignable to float64"},
+		} {
+			_, err := argumentOf(tc.arg, tc.t)
+			require.EqualError(t, err, tc.expected)
+		}
+	})
 }